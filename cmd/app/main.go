@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
@@ -34,8 +36,145 @@ func run() int {
 func core(logger *slog.Logger, logLevel *slog.LevelVar) error {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
+	if len(os.Args) > 1 && os.Args[1] == "config" {
+		// validate-pr-review-app config lint|effective|diff checks the config.
+		return configCommand(ctx, logger, os.Args[2:])
+	}
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		// validate-pr-review-app replay <captured requests> replays webhook requests captured with WEBHOOK_CAPTURE.
+		return replay(ctx, logger, os.Args[2:])
+	}
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		// validate-pr-review-app validate [--apply] <owner>/<repo>#<number> validates the pull request without webhooks.
+		return validate(ctx, logger, os.Args[2:])
+	}
+	if len(os.Args) > 1 && os.Args[1] == "simulate" {
+		// validate-pr-review-app simulate <candidate config> <owner>/<repo>... reports pull requests whose outcomes the candidate config changes.
+		return simulate(ctx, logger, os.Args[2:])
+	}
 	if err := entrypoint.Run(ctx, logger, logLevel, os.Getenv, version); err != nil {
 		return fmt.Errorf("run entrypoint: %w", err)
 	}
 	return nil
 }
+
+func validate(ctx context.Context, logger *slog.Logger, args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: validate-pr-review-app validate [--format text|json] [--apply] [--carry-forward] [--snapshot <file>] <owner>/<repo>#<number>")
+		fmt.Fprintln(fs.Output(), "       validate-pr-review-app validate [--format text|json] [--carry-forward] --from-snapshot <file>")
+		fs.PrintDefaults()
+	}
+	input := &entrypoint.ValidateInput{}
+	fs.StringVar(&input.Format, "format", entrypoint.FormatText, "output format (text or json)")
+	fs.BoolVar(&input.Apply, "apply", false, "create the check run with the result")
+	fs.BoolVar(&input.CarryForward, "carry-forward", false, "validate the pull request as if new commits were pushed")
+	fs.StringVar(&input.Snapshot, "snapshot", "", "write the snapshot of the pull request to the file")
+	fs.StringVar(&input.FromSnapshot, "from-snapshot", "", "validate the pull request in the snapshot file without GitHub")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return fmt.Errorf("parse arguments: %w", err)
+	}
+	switch {
+	case input.FromSnapshot != "" && fs.NArg() == 0:
+	case input.FromSnapshot == "" && fs.NArg() == 1:
+		input.PR = fs.Arg(0)
+	default:
+		fs.Usage()
+		return errors.New("either the pull request or --from-snapshot is required")
+	}
+	if err := entrypoint.Validate(ctx, logger, os.Stdout, os.Getenv, version, input); err != nil {
+		return fmt.Errorf("validate the pull request: %w", err)
+	}
+	return nil
+}
+
+func replay(ctx context.Context, logger *slog.Logger, args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: validate-pr-review-app replay [--url <url>] [--dry-run] [--format text|json] <JSONL file or directory>")
+		fs.PrintDefaults()
+	}
+	input := &entrypoint.ReplayInput{}
+	fs.StringVar(&input.URL, "url", "", "post requests to the URL instead of handling them in the process")
+	fs.BoolVar(&input.DryRun, "dry-run", false, "output decisions without creating check runs")
+	fs.StringVar(&input.Format, "format", entrypoint.FormatText, "output format (text or json)")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return fmt.Errorf("parse arguments: %w", err)
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("the captured requests are required")
+	}
+	input.Path = fs.Arg(0)
+	if err := entrypoint.Replay(ctx, logger, os.Stdout, os.Getenv, version, input); err != nil {
+		return fmt.Errorf("replay webhook requests: %w", err)
+	}
+	return nil
+}
+
+func simulate(ctx context.Context, logger *slog.Logger, args []string) error {
+	fs := flag.NewFlagSet("simulate", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: validate-pr-review-app simulate [--limit <n>] [--cache <dir>] [--offline] [--format text|json] <candidate config file> <owner>/<repo>[#<number>]...")
+		fs.PrintDefaults()
+	}
+	input := &entrypoint.SimulateInput{}
+	fs.IntVar(&input.Limit, "limit", 30, "the maximum number of recently merged pull requests per repository. Pull requests are ordered by the last update, so they're roughly the most recently merged ones") //nolint:mnd
+	fs.StringVar(&input.Cache, "cache", "", "the directory where pull requests are cached for repeat runs")
+	fs.BoolVar(&input.Offline, "offline", false, "read pull requests only from the cache")
+	fs.StringVar(&input.Format, "format", entrypoint.FormatText, "output format (text or json)")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return fmt.Errorf("parse arguments: %w", err)
+	}
+	if fs.NArg() < 2 { //nolint:mnd
+		fs.Usage()
+		return errors.New("the candidate config and repositories or pull requests are required")
+	}
+	input.Candidate = fs.Arg(0)
+	input.Targets = fs.Args()[1:]
+	if err := entrypoint.Simulate(ctx, logger, os.Stdout, os.Getenv, version, input); err != nil {
+		return fmt.Errorf("simulate the candidate config: %w", err)
+	}
+	return nil
+}
+
+const configUsage = `usage: validate-pr-review-app config lint [<config file>]
+       validate-pr-review-app config effective <owner>/<repo>
+       validate-pr-review-app config diff <old config file> <new config file>`
+
+func configCommand(ctx context.Context, logger *slog.Logger, args []string) error {
+	switch {
+	case len(args) == 1 && args[0] == "lint":
+		return lintConfig(ctx, "")
+	case len(args) == 2 && args[0] == "lint": //nolint:mnd
+		return lintConfig(ctx, args[1])
+	case len(args) == 2 && args[0] == "effective": //nolint:mnd
+		if err := entrypoint.PrintEffective(ctx, logger, os.Stdout, os.Getenv, args[1]); err != nil {
+			return fmt.Errorf("print the effective trust and insecure settings: %w", err)
+		}
+		return nil
+	case len(args) == 3 && args[0] == "diff": //nolint:mnd
+		if err := entrypoint.DiffConfig(os.Stdout, os.Getenv, args[1], args[2]); err != nil {
+			return fmt.Errorf("compare the configs: %w", err)
+		}
+		return nil
+	default:
+		return errors.New(configUsage)
+	}
+}
+
+func lintConfig(ctx context.Context, path string) error {
+	if err := entrypoint.LintConfig(ctx, os.Stdout, os.Getenv, path); err != nil {
+		return fmt.Errorf("lint the config: %w", err)
+	}
+	return nil
+}
@@ -65,6 +65,7 @@ func (h *Handler) handleProxy(ctx context.Context, req *ProxyRequest) (*events.A
 			Body:       "OK",
 		}, nil
 	}
+	h.reloadConfig(ctx, logger)
 	if err := h.controller.Run(ctx, logger, &controller.Request{
 		Body:      req.request.Body,
 		Headers:   req.request.Headers,
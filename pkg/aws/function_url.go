@@ -62,6 +62,7 @@ func (h *Handler) handleFunctionURL(ctx context.Context, req *FunctionURLRequest
 		return
 	}
 
+	h.reloadConfig(ctx, logger)
 	if err := h.controller.Run(ctx, logger, &controller.Request{
 		Body:      req.request.Body,
 		Headers:   req.request.Headers,
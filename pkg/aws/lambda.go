@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-lambda-go/lambdacontext"
@@ -16,19 +17,26 @@ import (
 
 type Handler struct {
 	logger     *slog.Logger
+	logLevel   *slog.LevelVar
 	config     *config.Config
+	source     *config.Source
 	controller Controller
+	checkedAt  time.Time
 }
 
 type Controller interface {
 	Run(ctx context.Context, logger *slog.Logger, req *controller.Request) error
+	SetConfig(cfg *config.Config)
 }
 
-func NewHandler(logger *slog.Logger, ctrl Controller, cfg *config.Config) (*Handler, error) {
+func NewHandler(logger *slog.Logger, logLevel *slog.LevelVar, ctrl Controller, cfg *config.Config, src *config.Source) (*Handler, error) {
 	return &Handler{
 		logger:     logger,
+		logLevel:   logLevel,
 		config:     cfg,
+		source:     src,
 		controller: ctrl,
+		checkedAt:  time.Now(),
 	}, nil
 }
 
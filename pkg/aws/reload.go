@@ -0,0 +1,35 @@
+package aws
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/logging"
+)
+
+// reloadConfig reloads the config in object storage if ObjectPollInterval has passed since the last check.
+// Lambda functions are frozen between invocations, so the config is checked when the function is invoked instead of in background.
+// Lambda handles an invocation at a time, so the handler doesn't need a lock.
+func (h *Handler) reloadConfig(ctx context.Context, logger *slog.Logger) {
+	if !h.source.IsObject() || time.Since(h.checkedAt) < config.ObjectPollInterval {
+		return
+	}
+	h.checkedAt = time.Now()
+	cfg, err := h.source.Reload(ctx, h.config)
+	if err != nil {
+		slogerr.WithError(logger, err).Error("failed to reload the config. The current config is kept", "config_hash", h.config.Hash)
+		return
+	}
+	if cfg == nil {
+		return
+	}
+	logger.Info("reloaded the config", "config_hash", cfg.Hash, "old_config_hash", h.config.Hash)
+	h.config = cfg
+	h.controller.SetConfig(cfg)
+	if err := logging.SetLevel(h.logLevel, cfg.LogLevel); err != nil {
+		slogerr.WithError(logger, err).Error("set the log level")
+	}
+}
@@ -0,0 +1,56 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+type S3 struct {
+	client *s3.Client
+}
+
+// NewS3 creates a S3 client.
+// S3-compatible storage such as MinIO can be used by the environment variable AWS_ENDPOINT_URL_S3.
+// If AWS_S3_USE_PATH_STYLE is true, path-style URLs are used.
+func NewS3(ctx context.Context, getEnv func(string) string) (*S3, error) {
+	cfg, err := NewConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read AWS config: %w", err)
+	}
+	client := s3.NewFromConfig(cfg, func(o *s3.Options) {
+		o.UsePathStyle = getEnv("AWS_S3_USE_PATH_STYLE") == "true"
+	})
+	return &S3{client: client}, nil
+}
+
+func (s *S3) GetObject(ctx context.Context, bucket, key, etag string) ([]byte, string, error) {
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	}
+	if etag != "" {
+		input.IfNoneMatch = aws.String(etag)
+	}
+	output, err := s.client.GetObject(ctx, input)
+	if err != nil {
+		var respErr *awshttp.ResponseError
+		if errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotModified {
+			return nil, "", config.ErrNotModified
+		}
+		return nil, "", fmt.Errorf("get an object from S3: %w", err)
+	}
+	defer output.Body.Close()
+	data, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("read an object from S3: %w", err)
+	}
+	return data, aws.ToString(output.ETag), nil
+}
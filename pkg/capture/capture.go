@@ -0,0 +1,170 @@
+// Package capture writes webhook requests to files and reads them to replay.
+package capture
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/controller"
+)
+
+// Redacted replaces values of headers which contain secrets or signatures.
+const Redacted = "REDACTED"
+
+// redactedHeaders are upper-cased names of headers to be redacted.
+var redactedHeaders = map[string]struct{}{ //nolint:gochecknoglobals
+	"AUTHORIZATION":            {},
+	"COOKIE":                   {},
+	"X-HUB-SIGNATURE":          {},
+	"X-HUB-SIGNATURE-256":      {},
+	"PROXY-AUTHORIZATION":      {},
+	"X-AMZ-SECURITY-TOKEN":     {},
+	"X-GOOG-IAP-JWT-ASSERTION": {},
+}
+
+// Delivery is a captured webhook request.
+type Delivery struct {
+	CapturedAt time.Time         `json:"captured_at"`
+	RequestID  string            `json:"request_id,omitempty"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+}
+
+// NewDelivery returns the delivery of the request with secrets redacted.
+func NewDelivery(req *controller.Request, now time.Time) *Delivery {
+	headers := make(map[string]string, len(req.Headers))
+	for k, v := range req.Headers {
+		if _, ok := redactedHeaders[strings.ToUpper(k)]; ok {
+			v = Redacted
+		}
+		headers[k] = v
+	}
+	return &Delivery{
+		CapturedAt: now.UTC(),
+		RequestID:  req.RequestID,
+		Headers:    headers,
+		Body:       req.Body,
+	}
+}
+
+// Writer writes deliveries to a JSONL file or a directory.
+// If the path ends with .jsonl, deliveries are appended to the file.
+// Otherwise, each delivery is written to a JSON file in the directory.
+type Writer struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewWriter(path string) *Writer {
+	return &Writer{path: path}
+}
+
+func (w *Writer) isJSONL() bool {
+	return strings.HasSuffix(w.path, ".jsonl")
+}
+
+// Write writes the delivery.
+func (w *Writer) Write(d *Delivery) error {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("marshal the delivery: %w", err)
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.isJSONL() {
+		return appendLine(w.path, b)
+	}
+	if err := os.MkdirAll(w.path, 0o755); err != nil { //nolint:mnd
+		return fmt.Errorf("create the directory: %w", err)
+	}
+	id := d.RequestID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	// File names are sorted by the captured time
+	name := d.CapturedAt.Format("20060102T150405.000000000Z") + "-" + sanitize(id) + ".json"
+	if err := os.WriteFile(filepath.Join(w.path, name), b, 0o600); err != nil { //nolint:mnd
+		return fmt.Errorf("write the delivery: %w", err)
+	}
+	return nil
+}
+
+func appendLine(path string, b []byte) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec,mnd
+	if err != nil {
+		return fmt.Errorf("open the file: %w", err)
+	}
+	if _, err := f.Write(append(b, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("write the delivery: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close the file: %w", err)
+	}
+	return nil
+}
+
+// sanitize replaces characters which can't be used in file names.
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
+
+type Runner interface {
+	Run(ctx context.Context, logger *slog.Logger, req *controller.Request) error
+	SetConfig(cfg *config.Config)
+	VerifySignature(req *controller.Request) error
+}
+
+// Controller captures requests before passing them to the controller.
+// Only requests with valid signatures are captured.
+type Controller struct {
+	Runner
+	writer *Writer
+	now    func() time.Time
+}
+
+// NewController returns a controller capturing requests with the writer.
+func NewController(ctrl Runner, writer *Writer) *Controller {
+	return &Controller{
+		Runner: ctrl,
+		writer: writer,
+		now:    time.Now,
+	}
+}
+
+// Run captures the request and passes it to the controller.
+// Failures of capturing don't stop handling the request.
+func (c *Controller) Run(ctx context.Context, logger *slog.Logger, req *controller.Request) error {
+	c.capture(logger, req)
+	return c.Runner.Run(ctx, logger, req) //nolint:wrapcheck
+}
+
+// capture writes the request if its signature is valid.
+// Unauthenticated requests must not be captured because replay signs captured requests with the webhook secret.
+func (c *Controller) capture(logger *slog.Logger, req *controller.Request) {
+	if err := c.Runner.VerifySignature(req); err != nil {
+		// The controller logs the invalid signature
+		logger.Debug("skip capturing the webhook request because the signature is invalid")
+		return
+	}
+	if err := c.writer.Write(NewDelivery(req, c.now())); err != nil {
+		slogerr.WithError(logger, err).Warn("capture the webhook request")
+	}
+}
@@ -0,0 +1,160 @@
+package capture_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/capture"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/controller"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	reqs := []*controller.Request{
+		{
+			Body: `{"action":"submitted"}`,
+			Headers: map[string]string{
+				"X-GitHub-Event":      "pull_request_review",
+				"X-Hub-Signature-256": "sha256=xxx",
+			},
+			RequestID: "1",
+		},
+		{
+			Body: `{"action":"synchronize"}`,
+			Headers: map[string]string{
+				"x-github-event":  "pull_request",
+				"x-hub-signature": "sha1=xxx",
+			},
+		},
+	}
+	want := []*capture.Delivery{
+		{
+			CapturedAt: now,
+			RequestID:  "1",
+			Headers: map[string]string{
+				"X-GitHub-Event":      "pull_request_review",
+				"X-Hub-Signature-256": capture.Redacted,
+			},
+			Body: `{"action":"submitted"}`,
+		},
+		{
+			CapturedAt: now.Add(time.Second),
+			Headers: map[string]string{
+				"x-github-event":  "pull_request",
+				"x-hub-signature": capture.Redacted,
+			},
+			Body: `{"action":"synchronize"}`,
+		},
+	}
+	for _, path := range []string{"deliveries.jsonl", "deliveries"} {
+		t.Run(path, func(t *testing.T) {
+			t.Parallel()
+			p := filepath.Join(t.TempDir(), path)
+			w := capture.NewWriter(p)
+			for i, req := range reqs {
+				if err := w.Write(capture.NewDelivery(req, now.Add(time.Duration(i)*time.Second))); err != nil {
+					t.Fatal(err)
+				}
+			}
+			got, err := capture.Read(p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Read() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelivery_Sign(t *testing.T) {
+	t.Parallel()
+	d := &capture.Delivery{
+		Headers: map[string]string{
+			"x-github-event":  "pull_request",
+			"x-hub-signature": capture.Redacted,
+		},
+		Body: `{"action":"synchronize"}`,
+	}
+	req := d.Sign([]byte("secret"))
+	if _, ok := req.Headers["x-hub-signature"]; ok {
+		t.Fatal("the redacted signature must be removed")
+	}
+	for _, key := range []string{"X-Hub-Signature", "X-Hub-Signature-256"} {
+		if err := github.ValidateSignature(req.Headers[key], []byte(req.Body), []byte("secret")); err != nil {
+			t.Errorf("%s is invalid: %v", key, err)
+		}
+	}
+	if err := github.ValidateSignature(req.Headers["X-Hub-Signature-256"], []byte(req.Body), []byte("other")); err == nil {
+		t.Error("the signature must not be valid with another secret")
+	}
+}
+
+type runner struct {
+	reqs      []*controller.Request
+	verifyErr error
+}
+
+func (r *runner) Run(_ context.Context, _ *slog.Logger, req *controller.Request) error {
+	r.reqs = append(r.reqs, req)
+	return nil
+}
+
+func (r *runner) SetConfig(_ *config.Config) {}
+
+func (r *runner) VerifySignature(_ *controller.Request) error {
+	return r.verifyErr
+}
+
+func TestController_Run(t *testing.T) {
+	t.Parallel()
+	p := filepath.Join(t.TempDir(), "deliveries.jsonl")
+	r := &runner{}
+	c := capture.NewController(r, capture.NewWriter(p))
+	req := &controller.Request{
+		Body:    `{}`,
+		Headers: map[string]string{"X-Hub-Signature": "sha1=xxx"},
+	}
+	if err := c.Run(t.Context(), slog.New(slog.DiscardHandler), req); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.reqs) != 1 || r.reqs[0].Headers["X-Hub-Signature"] != "sha1=xxx" {
+		t.Fatalf("the request must be passed to the controller as is: %+v", r.reqs)
+	}
+	got, err := capture.Read(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Headers["X-Hub-Signature"] != capture.Redacted {
+		t.Fatalf("the request must be captured with the signature redacted: %+v", got)
+	}
+}
+
+func TestController_Run_invalidSignature(t *testing.T) {
+	t.Parallel()
+	p := filepath.Join(t.TempDir(), "deliveries.jsonl")
+	r := &runner{verifyErr: errors.New("signature mismatch")}
+	c := capture.NewController(r, capture.NewWriter(p))
+	req := &controller.Request{
+		Body:    `{}`,
+		Headers: map[string]string{"X-Hub-Signature": "sha1=invalid"},
+	}
+	if err := c.Run(t.Context(), slog.New(slog.DiscardHandler), req); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.reqs) != 1 {
+		t.Fatalf("the request must be passed to the controller: %+v", r.reqs)
+	}
+	if _, err := os.Stat(p); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("the request with the invalid signature must not be captured: %v", err)
+	}
+}
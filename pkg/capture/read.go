@@ -0,0 +1,70 @@
+package capture
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// maxLineSize is the maximum size of a line of JSONL files. Webhook payloads can be up to 25 MB.
+const maxLineSize = 32 * 1024 * 1024
+
+// Read reads deliveries from the JSONL file or JSON files in the directory written by Writer.
+// Deliveries in the directory are sorted by file names, which start with the captured time.
+func Read(path string) ([]*Delivery, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("get the file info: %w", err)
+	}
+	if !fi.IsDir() {
+		return readJSONL(path)
+	}
+	paths, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("find delivery files: %w", err)
+	}
+	slices.Sort(paths)
+	deliveries := make([]*Delivery, len(paths))
+	for i, p := range paths {
+		b, err := os.ReadFile(p) //nolint:gosec
+		if err != nil {
+			return nil, fmt.Errorf("read a delivery file: %w", err)
+		}
+		d := &Delivery{}
+		if err := json.Unmarshal(b, d); err != nil {
+			return nil, fmt.Errorf("parse a delivery file %s: %w", p, err)
+		}
+		deliveries[i] = d
+	}
+	return deliveries, nil
+}
+
+func readJSONL(path string) ([]*Delivery, error) {
+	b, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("read the file: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	scanner.Buffer(nil, maxLineSize)
+	var deliveries []*Delivery
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		d := &Delivery{}
+		if err := json.Unmarshal(scanner.Bytes(), d); err != nil {
+			return nil, fmt.Errorf("parse a delivery at %s:%d: %w", path, line, err)
+		}
+		deliveries = append(deliveries, d)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read the file: %w", err)
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,39 @@
+package capture
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/controller"
+)
+
+// Sign returns the request of the delivery signed with the webhook secret.
+// Signature headers are replaced in the same way as GitHub signs webhooks.
+func (d *Delivery) Sign(secret []byte) *controller.Request {
+	headers := make(map[string]string, len(d.Headers)+2) //nolint:mnd
+	for k, v := range d.Headers {
+		switch strings.ToUpper(k) {
+		case "X-HUB-SIGNATURE", "X-HUB-SIGNATURE-256":
+			continue
+		}
+		headers[k] = v
+	}
+	body := []byte(d.Body)
+	headers["X-Hub-Signature"] = "sha1=" + sign(sha1.New, secret, body)
+	headers["X-Hub-Signature-256"] = "sha256=" + sign(sha256.New, secret, body)
+	return &controller.Request{
+		Body:      d.Body,
+		Headers:   headers,
+		RequestID: d.RequestID,
+	}
+}
+
+func sign(h func() hash.Hash, secret, body []byte) string {
+	mac := hmac.New(h, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
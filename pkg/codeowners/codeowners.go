@@ -0,0 +1,181 @@
+// Package codeowners parses CODEOWNERS files and finds the owners of files.
+// https://docs.github.com/en/repositories/managing-your-repositorys-settings-and-features/customizing-your-repository/about-code-owners
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Paths are the locations of CODEOWNERS files.
+// GitHub uses the first file found in this order.
+var Paths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"} //nolint:gochecknoglobals
+
+type Ruleset struct {
+	Rules []*Rule
+	// InvalidLines are lines skipped because their patterns aren't supported.
+	// Like GitHub, invalid lines are ignored instead of failing the whole file.
+	InvalidLines []*InvalidLine
+}
+
+// InvalidLine is a line of CODEOWNERS whose pattern isn't supported.
+type InvalidLine struct {
+	Line    int
+	Pattern string
+	Err     error
+}
+
+// Rule is a line of CODEOWNERS.
+// A rule without owners means the matched files have no owners.
+type Rule struct {
+	Pattern string
+	Owners  []string
+	pattern *Pattern
+}
+
+// Pattern is a path pattern of CODEOWNERS.
+type Pattern struct {
+	re *regexp.Regexp
+}
+
+// CompilePattern compiles a path pattern of CODEOWNERS.
+// The syntax follows gitignore, except that `!` negation and `[ ]` character ranges aren't supported.
+func CompilePattern(pattern string) (*Pattern, error) {
+	re, err := compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Pattern{re: re}, nil
+}
+
+// Match reports whether the file path matches the pattern.
+func (p *Pattern) Match(file string) bool {
+	return p.re.MatchString(strings.TrimPrefix(file, "/"))
+}
+
+// Parse parses a CODEOWNERS file.
+// Lines with unsupported patterns are skipped and recorded in InvalidLines.
+func Parse(content string) (*Ruleset, error) {
+	ruleset := &Ruleset{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	line := 0
+	for scanner.Scan() {
+		line++
+		fields := splitFields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		pattern, err := CompilePattern(fields[0])
+		if err != nil {
+			ruleset.InvalidLines = append(ruleset.InvalidLines, &InvalidLine{
+				Line:    line,
+				Pattern: fields[0],
+				Err:     err,
+			})
+			continue
+		}
+		ruleset.Rules = append(ruleset.Rules, &Rule{
+			Pattern: fields[0],
+			Owners:  fields[1:],
+			pattern: pattern,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read CODEOWNERS: %w", err)
+	}
+	return ruleset, nil
+}
+
+// Match returns the last rule matching the file path.
+// It returns nil if no rule matches.
+func (r *Ruleset) Match(file string) *Rule {
+	for i := len(r.Rules) - 1; i >= 0; i-- {
+		if r.Rules[i].pattern.Match(file) {
+			return r.Rules[i]
+		}
+	}
+	return nil
+}
+
+// splitFields splits a line into a pattern and owners, removing comments.
+// `\#` and `\ ` are treated as literal characters of the pattern.
+func splitFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	escaped := false
+	for _, r := range line {
+		if escaped {
+			field.WriteRune(r)
+			escaped = false
+			continue
+		}
+		switch r {
+		case '\\':
+			escaped = true
+			continue
+		case '#':
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+			}
+			return fields
+		case ' ', '\t':
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+			continue
+		}
+		field.WriteRune(r)
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// compile converts a CODEOWNERS pattern to a regular expression.
+func compile(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") || strings.ContainsAny(pattern, "[]") {
+		return nil, fmt.Errorf("unsupported pattern %q: negation and character ranges aren't supported", pattern)
+	}
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.TrimSuffix(pattern, "/")
+	anchored := strings.HasPrefix(p, "/") || strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(p); i++ {
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case p[i] == '*':
+			b.WriteString("[^/]*")
+		case p[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(p[i : i+1]))
+		}
+	}
+	switch {
+	case dirOnly:
+		// A directory pattern matches all files in the directory.
+		b.WriteString("/.*")
+	case strings.HasSuffix(p, "/*"):
+		// `docs/*` matches docs/a.md but not docs/a/b.md.
+	default:
+		// A pattern matches a file or all files in a directory.
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String()) //nolint:wrapcheck
+}
@@ -0,0 +1,164 @@
+package codeowners_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/codeowners"
+)
+
+const testCodeOwners = `# This is a comment.
+*       @global-owner
+*.js    @js-owner # This is an inline comment.
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+/scripts/ @org/scripts-team @doctocat
+**/logs @org/logs-team
+/apps/github
+\#file_with_pound.rb @pound-owner
+`
+
+func TestRuleset_Match(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	ruleset, err := codeowners.Parse(testCodeOwners)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		file string
+		want []string
+	}{
+		{
+			name: "default owner",
+			file: "README.md",
+			want: []string{"@global-owner"},
+		},
+		{
+			name: "extension",
+			file: "src/index.js",
+			want: []string{"@js-owner"},
+		},
+		{
+			name: "the last matching rule takes precedence",
+			file: "build/logs/a/b.txt",
+			want: []string{"@org/logs-team"},
+		},
+		{
+			name: "direct children of docs",
+			file: "docs/getting-started.md",
+			want: []string{"docs@example.com"},
+		},
+		{
+			name: "nested files of docs don't match docs/*",
+			file: "docs/build-app/troubleshooting.md",
+			want: []string{"@global-owner"},
+		},
+		{
+			name: "unanchored directory",
+			file: "foo/apps/main.go",
+			want: []string{"@octocat"},
+		},
+		{
+			name: "multiple owners",
+			file: "scripts/release.sh",
+			want: []string{"@org/scripts-team", "@doctocat"},
+		},
+		{
+			name: "double asterisk",
+			file: "a/b/logs/c.txt",
+			want: []string{"@org/logs-team"},
+		},
+		{
+			name: "rule without owners",
+			file: "apps/github/main.go",
+			want: []string{},
+		},
+		{
+			name: "escaped pound",
+			file: "#file_with_pound.rb",
+			want: []string{"@pound-owner"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rule := ruleset.Match(tt.file)
+			if rule == nil {
+				t.Fatal("no rule matches")
+			}
+			if diff := cmp.Diff(tt.want, rule.Owners); diff != "" {
+				t.Errorf("owners mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRuleset_Match_noRule(t *testing.T) {
+	t.Parallel()
+	ruleset, err := codeowners.Parse("/docs/ @octocat\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule := ruleset.Match("README.md"); rule != nil {
+		t.Errorf("Match() = %v, want nil", rule)
+	}
+}
+
+func TestParse_unsupportedPattern(t *testing.T) {
+	t.Parallel()
+	ruleset, err := codeowners.Parse("* @global-owner\n!foo @octocat\n[abc].txt @octocat\n*.js @js-owner\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invalid []string
+	for _, l := range ruleset.InvalidLines {
+		if l.Err == nil {
+			t.Errorf("the error of the line %d must be set", l.Line)
+		}
+		invalid = append(invalid, fmt.Sprintf("%d %s", l.Line, l.Pattern))
+	}
+	if diff := cmp.Diff([]string{"2 !foo", "3 [abc].txt"}, invalid); diff != "" {
+		t.Errorf("invalid lines mismatch (-want +got):\n%s", diff)
+	}
+	// Other rules are still resolved
+	for file, want := range map[string]string{"README.md": "@global-owner", "src/index.js": "@js-owner"} {
+		rule := ruleset.Match(file)
+		if rule == nil {
+			t.Errorf("Match(%q) = nil", file)
+			continue
+		}
+		if diff := cmp.Diff([]string{want}, rule.Owners); diff != "" {
+			t.Errorf("Match(%q) owners mismatch (-want +got):\n%s", file, diff)
+		}
+	}
+}
+
+func TestPattern_Match(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		pattern string
+		file    string
+		want    bool
+	}{
+		{pattern: ".github/workflows/**", file: ".github/workflows/test.yaml", want: true},
+		{pattern: ".github/workflows/**", file: ".github/actions/test/action.yaml", want: false},
+		{pattern: "terraform/prod/**", file: "terraform/prod/a/main.tf", want: true},
+		{pattern: "CODEOWNERS", file: ".github/CODEOWNERS", want: true},
+		{pattern: "/CODEOWNERS", file: ".github/CODEOWNERS", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.file, func(t *testing.T) {
+			t.Parallel()
+			p, err := codeowners.CompilePattern(tt.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := p.Match(tt.file); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/codeowners"
+)
+
+func (c *Config) initRequiredApprovals() error {
+	if err := validateRequiredApprovals(c.RequiredApprovals, c.RequiredApprovalsWithUntrustedCommits); err != nil {
+		return err
+	}
+	if c.RequiredApprovals == 0 {
+		c.RequiredApprovals = 1
+	}
+	if c.RequiredApprovalsWithUntrustedCommits == 0 {
+		c.RequiredApprovalsWithUntrustedCommits = c.RequiredApprovals + 1
+	}
+	if err := validateSensitivePaths(c.SensitivePaths, c.RequiredApprovalsForSensitivePaths); err != nil {
+		return err
+	}
+	c.RequiredApprovalsForSensitivePaths, c.requiredApprovalsForSensitivePathsSet = inheritRequiredApprovalsForSensitivePaths(
+		0, false, c.RequiredApprovalsForSensitivePaths, c.RequiredApprovalsWithUntrustedCommits)
+	return nil
+}
+
+func validateSensitivePaths(patterns []string, required int) error {
+	if required < 0 {
+		return errors.New("required_approvals_for_sensitive_paths must not be negative")
+	}
+	for _, pattern := range patterns {
+		if _, err := codeowners.CompilePattern(pattern); err != nil {
+			return fmt.Errorf("invalid sensitive path pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// inheritRequiredApprovals returns required_approvals and required_approvals_with_untrusted_commits of the layer falling back to the base.
+// If the layer sets only required_approvals, required_approvals_with_untrusted_commits defaults to one more than it like the root config,
+// unless the inherited value is greater.
+func inheritRequiredApprovals(baseRequired, baseWithUntrusted, required, withUntrusted int) (int, int) {
+	if required == 0 {
+		required = baseRequired
+		if withUntrusted == 0 {
+			withUntrusted = baseWithUntrusted
+		}
+		return required, withUntrusted
+	}
+	if withUntrusted == 0 {
+		withUntrusted = max(baseWithUntrusted, required+1)
+	}
+	return required, withUntrusted
+}
+
+// inheritRequiredApprovalsForSensitivePaths returns required_approvals_for_sensitive_paths of the layer falling back to the base and whether it's set explicitly.
+// If no layer sets it, it defaults to required_approvals_with_untrusted_commits of the layer.
+func inheritRequiredApprovalsForSensitivePaths(base int, baseSet bool, required, withUntrusted int) (int, bool) {
+	switch {
+	case required != 0:
+		return required, true
+	case baseSet:
+		return base, true
+	default:
+		return withUntrusted, false
+	}
+}
+
+func validateRequiredApprovals(required, requiredWithUntrustedCommits int) error {
+	if required < 0 {
+		return errors.New("required_approvals must not be negative")
+	}
+	if requiredWithUntrustedCommits < 0 {
+		return errors.New("required_approvals_with_untrusted_commits must not be negative")
+	}
+	if required != 0 && requiredWithUntrustedCommits != 0 && requiredWithUntrustedCommits < required {
+		return fmt.Errorf("required_approvals_with_untrusted_commits (%d) must be greater than or equal to required_approvals (%d)", requiredWithUntrustedCommits, required)
+	}
+	return nil
+}
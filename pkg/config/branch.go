@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BranchRule is a policy for pull requests whose base branches match Branches.
+// Settings fall back to the repository config.
+type BranchRule struct {
+	Branches                              []string  `json:"branches" yaml:"branches"`
+	Trust                                 *Trust    `json:"trust,omitempty" yaml:"trust"`
+	Insecure                              *Insecure `json:"insecure,omitempty" yaml:"insecure"`
+	RequiredApprovals                     int       `json:"required_approvals,omitempty" yaml:"required_approvals"`
+	RequiredApprovalsWithUntrustedCommits int       `json:"required_approvals_with_untrusted_commits,omitempty" yaml:"required_approvals_with_untrusted_commits"`
+	RequireCodeOwnerApprovals             *bool     `json:"require_code_owner_approvals,omitempty" yaml:"require_code_owner_approvals"`
+	RequiredApprovalsForSensitivePaths    int       `json:"required_approvals_for_sensitive_paths,omitempty" yaml:"required_approvals_for_sensitive_paths"`
+	BlockOnChangesRequested               *bool     `json:"block_on_changes_requested,omitempty" yaml:"block_on_changes_requested"`
+	matcher                               *Matcher
+}
+
+func (b *BranchRule) Validate() error {
+	if len(b.Branches) == 0 {
+		return errors.New("branches is required")
+	}
+	matcher, err := NewBranchMatcher(b.Branches)
+	if err != nil {
+		return fmt.Errorf("branches: %w", err)
+	}
+	b.matcher = matcher
+	if b.Trust != nil {
+		if err := b.Trust.Validate(); err != nil {
+			return fmt.Errorf("validate trust config: %w", err)
+		}
+	}
+	if b.Insecure != nil {
+		if err := b.Insecure.Validate(); err != nil {
+			return fmt.Errorf("validate insecure config: %w", err)
+		}
+	}
+	return validateSensitivePaths(nil, b.RequiredApprovalsForSensitivePaths)
+}
+
+// Match reports whether the base branch matches the rule.
+func (b *BranchRule) Match(branch string) bool {
+	return b.matcher.Match(branch)
+}
+
+// GetBranchRule returns the first branch rule matching the base branch.
+// Unset fields of the returned rule fall back to the repository config.
+// It returns nil if no rule matches.
+func (r *Repository) GetBranchRule(branch string) *BranchRule {
+	for _, b := range r.Branches {
+		if b.Match(branch) {
+			return r.inheritBranch(b)
+		}
+	}
+	return nil
+}
+
+// initBranches validates branch rules.
+func (r *Repository) initBranches() error {
+	for _, b := range r.Branches {
+		if err := b.Validate(); err != nil {
+			return fmt.Errorf("validate a branch rule: %w", err)
+		}
+		if err := validateRequiredApprovals(b.RequiredApprovals, b.RequiredApprovalsWithUntrustedCommits); err != nil {
+			return fmt.Errorf("validate a branch rule: %w", err)
+		}
+		if b.Trust != nil && b.Trust.TrustedApps != nil {
+			// Append the [bot] suffix in advance like the root config.
+			if err := b.Trust.Init(); err != nil {
+				return fmt.Errorf("initialize trust config of a branch rule: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// inheritBranch returns a copy of the branch rule falling back to the repository config.
+func (r *Repository) inheritBranch(rule *BranchRule) *BranchRule {
+	b := *rule
+	b.RequiredApprovals, b.RequiredApprovalsWithUntrustedCommits = inheritRequiredApprovals(
+		r.RequiredApprovals, r.RequiredApprovalsWithUntrustedCommits,
+		b.RequiredApprovals, b.RequiredApprovalsWithUntrustedCommits)
+	b.RequiredApprovalsForSensitivePaths, _ = inheritRequiredApprovalsForSensitivePaths(
+		r.RequiredApprovalsForSensitivePaths, r.requiredApprovalsForSensitivePathsSet,
+		b.RequiredApprovalsForSensitivePaths, b.RequiredApprovalsWithUntrustedCommits)
+	return &b
+}
+
+// validateBranches validates branch rules of the merged repository config.
+func (r *Repository) validateBranches() error {
+	for _, rule := range r.Branches {
+		b := r.inheritBranch(rule)
+		if err := validateRequiredApprovals(b.RequiredApprovals, b.RequiredApprovalsWithUntrustedCommits); err != nil {
+			return fmt.Errorf("validate a branch rule: %w", err)
+		}
+	}
+	return nil
+}
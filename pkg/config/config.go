@@ -6,17 +6,36 @@ import (
 )
 
 type Config struct {
-	AppID          int64                         `json:"app_id" yaml:"app_id"`
-	InstallationID int64                         `json:"installation_id" yaml:"installation_id"`
-	AWS            *AWS                          `json:"aws,omitempty" yaml:"aws"`
-	GoogleCloud    *GoogleCloud                  `json:"google_cloud,omitempty" yaml:"google_cloud"`
-	CheckName      string                        `json:"check_name,omitempty" yaml:"check_name"`
-	Trust          *Trust                        `json:"trust,omitempty" yaml:"trust"`
-	Insecure       *Insecure                     `json:"insecure,omitempty" yaml:"insecure"`
-	Templates      map[string]string             `json:"templates,omitempty" yaml:"templates"`
-	BuiltTemplates map[string]*template.Template `json:"-" yaml:"-"`
-	LogLevel       string                        `json:"log_level,omitempty" yaml:"log_level"`
-	Repositories   []*Repository                 `json:"repositories,omitempty" yaml:"repositories"`
+	// Include is a list of file paths or glob patterns of configs merged into the config.
+	// It's resolved when the config is read, so it isn't decoded.
+	Include                               []string                      `json:"include,omitempty" yaml:"-"`
+	AppID                                 int64                         `json:"app_id" yaml:"app_id"`
+	InstallationID                        int64                         `json:"installation_id" yaml:"installation_id"`
+	AWS                                   *AWS                          `json:"aws,omitempty" yaml:"aws"`
+	GoogleCloud                           *GoogleCloud                  `json:"google_cloud,omitempty" yaml:"google_cloud"`
+	CheckName                             string                        `json:"check_name,omitempty" yaml:"check_name"`
+	Trust                                 *Trust                        `json:"trust,omitempty" yaml:"trust"`
+	Insecure                              *Insecure                     `json:"insecure,omitempty" yaml:"insecure"`
+	Templates                             map[string]string             `json:"templates,omitempty" yaml:"templates"`
+	BuiltTemplates                        map[string]*template.Template `json:"-" yaml:"-"`
+	LogLevel                              string                        `json:"log_level,omitempty" yaml:"log_level"`
+	Repositories                          []*Repository                 `json:"repositories,omitempty" yaml:"repositories"`
+	RequiredApprovals                     int                           `json:"required_approvals,omitempty" yaml:"required_approvals"`
+	RequiredApprovalsWithUntrustedCommits int                           `json:"required_approvals_with_untrusted_commits,omitempty" yaml:"required_approvals_with_untrusted_commits"`
+	RequireCodeOwnerApprovals             bool                          `json:"require_code_owner_approvals,omitempty" yaml:"require_code_owner_approvals"`
+	SensitivePaths                        []string                      `json:"sensitive_paths,omitempty" yaml:"sensitive_paths"`
+	RequiredApprovalsForSensitivePaths    int                           `json:"required_approvals_for_sensitive_paths,omitempty" yaml:"required_approvals_for_sensitive_paths"`
+	BlockOnChangesRequested               bool                          `json:"block_on_changes_requested,omitempty" yaml:"block_on_changes_requested"`
+	SignaturePolicy                       *SignaturePolicy              `json:"signature_policy,omitempty" yaml:"signature_policy"`
+	Keyring                               *Keyring                      `json:"keyring,omitempty" yaml:"keyring"`
+	Gitsign                               *Gitsign                      `json:"gitsign,omitempty" yaml:"gitsign"`
+	RepoFile                              *RepoFilePolicy               `json:"repo_file,omitempty" yaml:"repo_file"`
+	// Tests are test cases run when the config is initialized
+	Tests []*Test `json:"tests,omitempty" yaml:"tests"`
+	// Hash is the SHA256 hash of the raw config and files it refers to
+	Hash string `json:"-" yaml:"-"`
+	// requiredApprovalsForSensitivePathsSet is true if required_approvals_for_sensitive_paths is set explicitly
+	requiredApprovalsForSensitivePathsSet bool
 }
 
 func (c *Config) Init() error {
@@ -26,7 +45,9 @@ func (c *Config) Init() error {
 	if err := c.Trust.Validate(); err != nil {
 		return fmt.Errorf("validate trust config: %w", err)
 	}
-	c.Trust.Init()
+	if err := c.Trust.Init(); err != nil {
+		return fmt.Errorf("initialize trust config: %w", err)
+	}
 	if c.CheckName == "" {
 		c.CheckName = "validate-review"
 	}
@@ -41,13 +62,38 @@ func (c *Config) Init() error {
 		}
 	}
 
-	if err := c.initRepos(); err != nil {
+	if c.SignaturePolicy != nil {
+		if err := c.SignaturePolicy.Validate(); err != nil {
+			return fmt.Errorf("validate signature_policy: %w", err)
+		}
+	}
+
+	if c.Keyring != nil {
+		if err := c.Keyring.Init(); err != nil {
+			return fmt.Errorf("initialize keyring: %w", err)
+		}
+	}
+
+	if c.Gitsign != nil {
+		if err := c.Gitsign.Init(); err != nil {
+			return fmt.Errorf("initialize gitsign: %w", err)
+		}
+	}
+
+	if c.RepoFile != nil {
+		if err := c.RepoFile.Validate(); err != nil {
+			return fmt.Errorf("validate repo_file: %w", err)
+		}
+	}
+
+	if err := c.initRequiredApprovals(); err != nil {
 		return err
 	}
-	if err := c.initTemplates(); err != nil {
+
+	if err := c.initRepos(); err != nil {
 		return err
 	}
-	if err := c.testUntrustedMachineUsers(); err != nil {
+	if err := c.initTemplates(); err != nil {
 		return err
 	}
 	return c.testTemplate()
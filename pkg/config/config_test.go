@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
 )
 
 func TestConfig_Init(t *testing.T) { //nolint:gocognit,cyclop
@@ -199,3 +200,287 @@ func TestConfig_Init_NilTemplates(t *testing.T) {
 		t.Error("Templates should be populated with default templates")
 	}
 }
+
+func TestConfig_Init_RequiredApprovals(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	tests := []struct {
+		name                      string
+		config                    *config.Config
+		wantRequired              int
+		wantRequiredWithUntrusted int
+		wantRepoRequired          int
+		wantRepoWithUntrusted     int
+		wantErr                   bool
+	}{
+		{
+			name:                      "defaults",
+			config:                    &config.Config{},
+			wantRequired:              1,
+			wantRequiredWithUntrusted: 2,
+		},
+		{
+			name: "required_approvals only",
+			config: &config.Config{
+				RequiredApprovals: 2,
+			},
+			wantRequired:              2,
+			wantRequiredWithUntrusted: 3,
+		},
+		{
+			name: "accept one approval in every case",
+			config: &config.Config{
+				RequiredApprovals:                     1,
+				RequiredApprovalsWithUntrustedCommits: 1,
+			},
+			wantRequired:              1,
+			wantRequiredWithUntrusted: 1,
+		},
+		{
+			name: "repository falls back to the root config",
+			config: &config.Config{
+				RequiredApprovals:                     2,
+				RequiredApprovalsWithUntrustedCommits: 3,
+				Repositories: []*config.Repository{
+					{
+						Repositories:      []string{"org/sandbox"},
+						Trust:             &config.Trust{},
+						RequiredApprovals: 1,
+					},
+				},
+			},
+			wantRequired:              2,
+			wantRequiredWithUntrusted: 3,
+			wantRepoRequired:          1,
+			wantRepoWithUntrusted:     3,
+		},
+		{
+			name: "negative required_approvals",
+			config: &config.Config{
+				RequiredApprovals: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "required_approvals_with_untrusted_commits is less than required_approvals",
+			config: &config.Config{
+				RequiredApprovals:                     3,
+				RequiredApprovalsWithUntrustedCommits: 2,
+			},
+			wantErr: true,
+		},
+		{
+			name: "repository required_approvals derives required_approvals_with_untrusted_commits",
+			config: &config.Config{
+				Repositories: []*config.Repository{
+					{
+						Repositories:      []string{"org/prod"},
+						Trust:             &config.Trust{},
+						RequiredApprovals: 2,
+					},
+				},
+			},
+			wantRequired:              1,
+			wantRequiredWithUntrusted: 2,
+			wantRepoRequired:          2,
+			wantRepoWithUntrusted:     3,
+		},
+		{
+			name: "repository required_approvals is greater than the root required_approvals_with_untrusted_commits",
+			config: &config.Config{
+				Repositories: []*config.Repository{
+					{
+						Repositories:      []string{"org/prod"},
+						Trust:             &config.Trust{},
+						RequiredApprovals: 3,
+					},
+				},
+			},
+			wantRequired:              1,
+			wantRequiredWithUntrusted: 2,
+			wantRepoRequired:          3,
+			wantRepoWithUntrusted:     4,
+		},
+		{
+			name: "branch rule required_approvals_with_untrusted_commits is less than the inherited required_approvals",
+			config: &config.Config{
+				Repositories: []*config.Repository{
+					{
+						Repositories:      []string{"org/prod"},
+						Trust:             &config.Trust{},
+						RequiredApprovals: 3,
+						Branches: []*config.BranchRule{
+							{
+								Branches:                              []string{"main"},
+								RequiredApprovalsWithUntrustedCommits: 2,
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "combination of repository configs is invalid",
+			config: &config.Config{
+				Repositories: []*config.Repository{
+					{
+						Repositories: []string{"org/*"},
+						Trust:        &config.Trust{},
+						Branches: []*config.BranchRule{
+							{
+								Branches:                              []string{"main"},
+								RequiredApprovalsWithUntrustedCommits: 2,
+							},
+						},
+					},
+					{
+						Repositories:      []string{"org/prod"},
+						Trust:             &config.Trust{},
+						RequiredApprovals: 3,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "repository required_approvals_with_untrusted_commits is less than its required_approvals",
+			config: &config.Config{
+				Repositories: []*config.Repository{
+					{
+						Repositories:                          []string{"org/prod"},
+						Trust:                                 &config.Trust{},
+						RequiredApprovals:                     3,
+						RequiredApprovalsWithUntrustedCommits: 2,
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.config.Init()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Config.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			repos := getRepos(t, tt.config)
+			if tt.config.RequiredApprovals != tt.wantRequired {
+				t.Errorf("RequiredApprovals = %d, want %d", tt.config.RequiredApprovals, tt.wantRequired)
+			}
+			if tt.config.RequiredApprovalsWithUntrustedCommits != tt.wantRequiredWithUntrusted {
+				t.Errorf("RequiredApprovalsWithUntrustedCommits = %d, want %d", tt.config.RequiredApprovalsWithUntrustedCommits, tt.wantRequiredWithUntrusted)
+			}
+			for _, repo := range repos {
+				if repo.RequiredApprovals != tt.wantRepoRequired {
+					t.Errorf("repository RequiredApprovals = %d, want %d", repo.RequiredApprovals, tt.wantRepoRequired)
+				}
+				if repo.RequiredApprovalsWithUntrustedCommits != tt.wantRepoWithUntrusted {
+					t.Errorf("repository RequiredApprovalsWithUntrustedCommits = %d, want %d", repo.RequiredApprovalsWithUntrustedCommits, tt.wantRepoWithUntrusted)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_Init_SensitivePaths(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		config       *config.Config
+		wantRequired int
+		wantRepo     []string
+		wantErr      bool
+	}{
+		{
+			name:         "defaults to required_approvals_with_untrusted_commits",
+			config:       &config.Config{RequiredApprovals: 2},
+			wantRequired: 3,
+		},
+		{
+			name: "repository falls back to the root config",
+			config: &config.Config{
+				SensitivePaths:                     []string{".github/workflows/**"},
+				RequiredApprovalsForSensitivePaths: 2,
+				Repositories: []*config.Repository{
+					{
+						Repositories: []string{"org/infra"},
+						Trust:        &config.Trust{},
+					},
+				},
+			},
+			wantRequired: 2,
+			wantRepo:     []string{".github/workflows/**"},
+		},
+		{
+			name: "invalid pattern",
+			config: &config.Config{
+				SensitivePaths: []string{"[abc]"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid repository pattern",
+			config: &config.Config{
+				Repositories: []*config.Repository{
+					{
+						Repositories:   []string{"org/infra"},
+						Trust:          &config.Trust{},
+						SensitivePaths: []string{"!terraform/"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative required_approvals_for_sensitive_paths",
+			config: &config.Config{
+				RequiredApprovalsForSensitivePaths: -1,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.config.Init()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Config.Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			repos := getRepos(t, tt.config)
+			if tt.config.RequiredApprovalsForSensitivePaths != tt.wantRequired {
+				t.Errorf("RequiredApprovalsForSensitivePaths = %d, want %d", tt.config.RequiredApprovalsForSensitivePaths, tt.wantRequired)
+			}
+			for _, repo := range repos {
+				if repo.RequiredApprovalsForSensitivePaths != tt.wantRequired {
+					t.Errorf("repository RequiredApprovalsForSensitivePaths = %d, want %d", repo.RequiredApprovalsForSensitivePaths, tt.wantRequired)
+				}
+				if diff := cmp.Diff(tt.wantRepo, repo.SensitivePaths); diff != "" {
+					t.Errorf("repository SensitivePaths mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+// getRepos returns the effective config of the first repository of each repository config.
+func getRepos(t *testing.T, cfg *config.Config) []*config.Repository {
+	t.Helper()
+	repos := make([]*config.Repository, 0, len(cfg.Repositories))
+	for _, r := range cfg.Repositories {
+		repo, err := cfg.GetRepo(&github.Repository{FullName: r.Repositories[0]})
+		if err != nil {
+			t.Fatal(err)
+		}
+		repos = append(repos, repo)
+	}
+	return repos
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+// otherRepos is the pattern representing repositories which no repository name pattern of the configs matches literally.
+const otherRepos = "*/*"
+
+// RepoDiff is changes of effective settings of repositories matching a repository name pattern.
+type RepoDiff struct {
+	Pattern string    `json:"pattern"`
+	Changes []*Change `json:"changes"`
+}
+
+// Change is a change of an effective setting.
+// Values are JSON. An empty value means the setting is unset.
+type Change struct {
+	Setting string `json:"setting"`
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+}
+
+// Diff returns changes of effective settings per repository name pattern of both configs.
+// Globs are evaluated as repository names, so `my-org/*` represents repositories which only `my-org/*` and broader patterns match.
+// `*/*` represents the other repositories.
+// Regular expressions and negations aren't evaluated, and repositories are evaluated without metadata,
+// so repository configs with selector don't match them.
+func Diff(oldCfg, newCfg *Config) ([]*RepoDiff, error) {
+	var diffs []*RepoDiff
+	for _, pattern := range diffPatterns(oldCfg, newCfg) {
+		oldSettings, err := oldCfg.effectiveSettings(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("get effective settings of %s in the old config: %w", pattern, err)
+		}
+		newSettings, err := newCfg.effectiveSettings(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("get effective settings of %s in the new config: %w", pattern, err)
+		}
+		keys := maps.Clone(oldSettings)
+		maps.Copy(keys, newSettings)
+		var changes []*Change
+		for _, key := range slices.Sorted(maps.Keys(keys)) {
+			if oldSettings[key] != newSettings[key] {
+				changes = append(changes, &Change{Setting: key, Old: oldSettings[key], New: newSettings[key]})
+			}
+		}
+		if changes != nil {
+			diffs = append(diffs, &RepoDiff{Pattern: pattern, Changes: changes})
+		}
+	}
+	return diffs, nil
+}
+
+// diffPatterns returns sorted lower-cased repository name patterns of the configs, which are evaluated as repository names.
+func diffPatterns(cfgs ...*Config) []string {
+	patterns := map[string]struct{}{otherRepos: {}}
+	for _, cfg := range cfgs {
+		for _, repo := range cfg.Repositories {
+			for _, s := range repo.Repositories {
+				if strings.HasPrefix(s, "!") || isRegexpPattern(s) {
+					continue
+				}
+				patterns[strings.ToLower(s)] = struct{}{}
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(patterns))
+}
+
+// effectiveSettings returns JSON of effective settings of the repository per setting name such as trust.trusted_apps.
+func (c *Config) effectiveSettings(repo string) (map[string]string, error) {
+	r, err := c.GetEffectiveRepo(&github.Repository{FullName: repo})
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return nil, fmt.Errorf("marshal the repository config: %w", err)
+	}
+	m := map[string]any{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("unmarshal the repository config: %w", err)
+	}
+	delete(m, "repositories")
+	settings := map[string]string{}
+	if err := flattenSettings(settings, "", m); err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// flattenSettings flattens nested trust and insecure settings so that changes are reported per setting.
+func flattenSettings(settings map[string]string, prefix string, m map[string]any) error {
+	for key, value := range m {
+		if v, ok := value.(map[string]any); ok && (key == "trust" || key == "insecure") {
+			if err := flattenSettings(settings, prefix+key+".", v); err != nil {
+				return err
+			}
+			continue
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("marshal the setting %s: %w", prefix+key, err)
+		}
+		settings[prefix+key] = string(b)
+	}
+	return nil
+}
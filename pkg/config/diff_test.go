@@ -0,0 +1,65 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	oldCfg := &config.Config{}
+	if err := config.Parse(oldCfg, []byte(`
+app_id: 1
+installation_id: 2
+required_approvals: 1
+repositories:
+  - repositories: [my-org/*]
+    trust:
+      trusted_apps: [foo]
+  - repositories: [my-org/legacy]
+    trust: {}
+    ignored: true
+`)); err != nil {
+		t.Fatal(err)
+	}
+	newCfg := &config.Config{}
+	if err := config.Parse(newCfg, []byte(`
+app_id: 1
+installation_id: 2
+required_approvals: 1
+repositories:
+  - repositories: [my-org/*]
+    trust:
+      trusted_apps: [foo, bar]
+    insecure:
+      allow_unsigned_commits: false
+`)); err != nil {
+		t.Fatal(err)
+	}
+	got, err := config.Diff(oldCfg, newCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*config.RepoDiff{
+		{
+			Pattern: "my-org/*",
+			Changes: []*config.Change{
+				{Setting: "insecure.allow_unsigned_commits", New: "false"},
+				{Setting: "trust.trusted_apps", Old: `["foo[bot]"]`, New: `["foo[bot]","bar[bot]"]`},
+			},
+		},
+		{
+			Pattern: "my-org/legacy",
+			Changes: []*config.Change{
+				{Setting: "ignored", Old: "true"},
+				{Setting: "insecure.allow_unsigned_commits", New: "false"},
+				{Setting: "trust.trusted_apps", Old: `["foo[bot]"]`, New: `["foo[bot]","bar[bot]"]`},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,64 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/gitsign"
+)
+
+// Gitsign verifies keyless commit signatures created by gitsign.
+// GitHub can't verify them, so the app verifies them offline with the configured Fulcio trust roots.
+type Gitsign struct {
+	// FulcioRoots are PEM encoded root certificates of Fulcio.
+	FulcioRoots string `json:"fulcio_roots" yaml:"fulcio_roots"`
+	// FulcioIntermediates are PEM encoded intermediate certificates of Fulcio.
+	FulcioIntermediates string `json:"fulcio_intermediates,omitempty" yaml:"fulcio_intermediates"`
+	// Identities are allowed certificate identities per login.
+	Identities map[string][]*GitsignIdentity `json:"identities" yaml:"identities"`
+	Built      *gitsign.Verifier             `json:"-" yaml:"-"`
+}
+
+// GitsignIdentity is a certificate identity issued by Fulcio.
+type GitsignIdentity struct {
+	// Subject is an email address or a URI of the certificate.
+	Subject string `json:"subject" yaml:"subject"`
+	// Issuer is the OIDC issuer such as https://github.com/login/oauth.
+	Issuer string `json:"issuer" yaml:"issuer"`
+}
+
+// Init validates the config and parses certificates.
+func (g *Gitsign) Init() error {
+	if g.FulcioRoots == "" {
+		return errors.New("fulcio_roots is required")
+	}
+	if len(g.Identities) == 0 {
+		return errors.New("identities is required")
+	}
+	logins := make([]string, 0, len(g.Identities))
+	identities := make(map[string][]*gitsign.Identity, len(g.Identities))
+	for login, ids := range g.Identities {
+		if len(ids) == 0 {
+			return fmt.Errorf("identities of %s is empty", login)
+		}
+		for _, id := range ids {
+			if id.Subject == "" || id.Issuer == "" {
+				return fmt.Errorf("subject and issuer are required: %s", login)
+			}
+			identities[login] = append(identities[login], &gitsign.Identity{
+				Subject: id.Subject,
+				Issuer:  id.Issuer,
+			})
+		}
+		logins = append(logins, login)
+	}
+	if err := validateLoginNames(logins, "identities"); err != nil {
+		return err
+	}
+	v, err := gitsign.New(g.FulcioRoots, g.FulcioIntermediates, identities)
+	if err != nil {
+		return fmt.Errorf("parse certificates: %w", err)
+	}
+	g.Built = v
+	return nil
+}
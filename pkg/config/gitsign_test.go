@@ -0,0 +1,91 @@
+package config_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+func newRootPEM(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sigstore"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestGitsign_Init(t *testing.T) {
+	t.Parallel()
+	root := newRootPEM(t)
+	identities := map[string][]*config.GitsignIdentity{
+		"octocat": {{Subject: "octocat@example.com", Issuer: "https://github.com/login/oauth"}},
+	}
+	tests := []struct {
+		name    string
+		input   *config.Gitsign
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			input: &config.Gitsign{FulcioRoots: root, Identities: identities},
+		},
+		{
+			name:    "fulcio_roots is required",
+			input:   &config.Gitsign{Identities: identities},
+			wantErr: true,
+		},
+		{
+			name:    "invalid fulcio_roots",
+			input:   &config.Gitsign{FulcioRoots: "invalid", Identities: identities},
+			wantErr: true,
+		},
+		{
+			name:    "identities is required",
+			input:   &config.Gitsign{FulcioRoots: root},
+			wantErr: true,
+		},
+		{
+			name: "issuer is required",
+			input: &config.Gitsign{
+				FulcioRoots: root,
+				Identities: map[string][]*config.GitsignIdentity{
+					"octocat": {{Subject: "octocat@example.com"}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.input.Init()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && tt.input.Built == nil {
+				t.Error("Built should be set")
+			}
+		})
+	}
+}
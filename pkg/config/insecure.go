@@ -1,11 +1,16 @@
 package config
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 type Insecure struct {
-	AllowUnsignedCommits       *bool    `json:"allow_unsigned_commits,omitempty" yaml:"allow_unsigned_commits"`
-	UnsignedCommitApps         []string `json:"unsigned_commit_apps,omitempty" yaml:"unsigned_commit_apps"`
-	UnsignedCommitMachineUsers []string `json:"unsigned_commit_machine_users,omitempty" yaml:"unsigned_commit_machine_users"`
+	AllowUnsignedCommits              *bool    `json:"allow_unsigned_commits,omitempty" yaml:"allow_unsigned_commits"`
+	UnsignedCommitApps                []string `json:"unsigned_commit_apps,omitempty" yaml:"unsigned_commit_apps"`
+	UnsignedCommitMachineUsers        []string `json:"unsigned_commit_machine_users,omitempty" yaml:"unsigned_commit_machine_users"`
+	UnsignedCommitAppsMatcher         *Matcher `json:"-" yaml:"-"`
+	UnsignedCommitMachineUsersMatcher *Matcher `json:"-" yaml:"-"`
 }
 
 func (i *Insecure) Validate() error {
@@ -14,11 +19,20 @@ func (i *Insecure) Validate() error {
 			return errors.New("allow_unsigned_commits cannot be used together with unsigned_commit_apps or unsigned_commit_machine_users")
 		}
 	}
-	if err := validateLoginNames(i.UnsignedCommitApps, "unsigned_commit_apps"); err != nil {
-		return err
+	return i.Init()
+}
+
+// Init compiles patterns.
+func (i *Insecure) Init() error {
+	apps, err := NewAppMatcher(i.UnsignedCommitApps)
+	if err != nil {
+		return fmt.Errorf("unsigned_commit_apps: %w", err)
 	}
-	if err := validateLoginNames(i.UnsignedCommitMachineUsers, "unsigned_commit_machine_users"); err != nil {
-		return err
+	users, err := NewUserMatcher(i.UnsignedCommitMachineUsers)
+	if err != nil {
+		return fmt.Errorf("unsigned_commit_machine_users: %w", err)
 	}
+	i.UnsignedCommitAppsMatcher = apps
+	i.UnsignedCommitMachineUsersMatcher = users
 	return nil
 }
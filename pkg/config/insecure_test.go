@@ -71,10 +71,16 @@ func TestInsecure_Validate(t *testing.T) { //nolint:funlen
 			wantErr: true,
 		},
 		{
-			name: "invalid unsigned_commit_apps with asterisk",
+			name: "unsigned_commit_apps with a glob",
 			input: &config.Insecure{
 				UnsignedCommitApps: []string{"renovate*"},
 			},
+		},
+		{
+			name: "invalid unsigned_commit_apps with a team reference",
+			input: &config.Insecure{
+				UnsignedCommitApps: []string{"@org/bots"},
+			},
 			wantErr: true,
 		},
 		{
@@ -85,9 +91,15 @@ func TestInsecure_Validate(t *testing.T) { //nolint:funlen
 			wantErr: true,
 		},
 		{
-			name: "invalid unsigned_commit_machine_users with asterisk",
+			name: "unsigned_commit_machine_users with a glob, a regular expression, and a team reference",
+			input: &config.Insecure{
+				UnsignedCommitMachineUsers: []string{"bot*", "/ci-[0-9]+/", "@org/bots"},
+			},
+		},
+		{
+			name: "invalid regular expression in unsigned_commit_machine_users",
 			input: &config.Insecure{
-				UnsignedCommitMachineUsers: []string{"bot*"},
+				UnsignedCommitMachineUsers: []string{"/ci-[0-9/"},
 			},
 			wantErr: true,
 		},
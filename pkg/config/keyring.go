@@ -0,0 +1,57 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/keyring"
+)
+
+// Keyring is public keys to verify commit signatures by the app itself.
+// Keys registered to GitHub accounts aren't trusted, so users can't sign commits with throwaway keys.
+type Keyring struct {
+	// Mode is either "additional" or "replace".
+	// additional: signatures must be verified by both the keyring and GitHub. This is the default.
+	// replace: signatures verified by the keyring are trusted regardless of GitHub's verification.
+	Mode string `json:"mode,omitempty" yaml:"mode"`
+	// GPGPublicKeys are armored GPG public keys per login.
+	GPGPublicKeys map[string][]string `json:"gpg_public_keys,omitempty" yaml:"gpg_public_keys"`
+	// SSHAllowedSigners are SSH allowed_signers entries per login.
+	SSHAllowedSigners map[string][]string `json:"ssh_allowed_signers,omitempty" yaml:"ssh_allowed_signers"`
+	Built             *keyring.Keyring    `json:"-" yaml:"-"`
+}
+
+const (
+	KeyringModeAdditional = "additional"
+	KeyringModeReplace    = "replace"
+)
+
+// Init validates the keyring and parses keys.
+func (k *Keyring) Init() error {
+	switch k.Mode {
+	case "":
+		k.Mode = KeyringModeAdditional
+	case KeyringModeAdditional, KeyringModeReplace:
+	default:
+		return fmt.Errorf("mode must be either additional or replace: %q", k.Mode)
+	}
+	if len(k.GPGPublicKeys) == 0 && len(k.SSHAllowedSigners) == 0 {
+		return errors.New("either gpg_public_keys or ssh_allowed_signers is required")
+	}
+	logins := make([]string, 0, len(k.GPGPublicKeys)+len(k.SSHAllowedSigners))
+	for login := range k.GPGPublicKeys {
+		logins = append(logins, login)
+	}
+	for login := range k.SSHAllowedSigners {
+		logins = append(logins, login)
+	}
+	if err := validateLoginNames(logins, "keyring"); err != nil {
+		return err
+	}
+	kr, err := keyring.New(k.GPGPublicKeys, k.SSHAllowedSigners)
+	if err != nil {
+		return fmt.Errorf("parse keys: %w", err)
+	}
+	k.Built = kr
+	return nil
+}
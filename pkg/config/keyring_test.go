@@ -0,0 +1,90 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+const sshPublicKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIMrO43tumbPBsfZ6CWcKtwavWf+tPof6L68H4kJGqGse"
+
+func TestKeyring_Init(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    *config.Keyring
+		wantMode string
+		wantErr  bool
+	}{
+		{
+			name: "default mode",
+			input: &config.Keyring{
+				SSHAllowedSigners: map[string][]string{
+					"octocat": {"octocat@example.com " + sshPublicKey},
+				},
+			},
+			wantMode: "additional",
+		},
+		{
+			name: "replace mode",
+			input: &config.Keyring{
+				Mode: "replace",
+				SSHAllowedSigners: map[string][]string{
+					"octocat": {sshPublicKey},
+				},
+			},
+			wantMode: "replace",
+		},
+		{
+			name: "unknown mode",
+			input: &config.Keyring{
+				Mode: "only",
+				SSHAllowedSigners: map[string][]string{
+					"octocat": {sshPublicKey},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "no key",
+			input:   &config.Keyring{},
+			wantErr: true,
+		},
+		{
+			name: "invalid login",
+			input: &config.Keyring{
+				SSHAllowedSigners: map[string][]string{
+					"octo*": {sshPublicKey},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid GPG key",
+			input: &config.Keyring{
+				GPGPublicKeys: map[string][]string{
+					"octocat": {"-----BEGIN PGP PUBLIC KEY BLOCK-----"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.input.Init()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Init() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.input.Mode != tt.wantMode {
+				t.Errorf("Mode = %q, want %q", tt.input.Mode, tt.wantMode)
+			}
+			if !tt.input.Built.Has("octocat") {
+				t.Error("the keyring should have keys of octocat")
+			}
+		})
+	}
+}
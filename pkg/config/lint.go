@@ -0,0 +1,314 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Issue is a problem of the config found by Lint.
+// The config is valid, but it probably doesn't work as intended.
+type Issue struct {
+	// Path is the location of the setting like repositories[1].trust.trusted_apps
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (i *Issue) String() string {
+	return i.Path + ": " + i.Message
+}
+
+// Lint returns issues of the initialized config.
+// It reports duplicate patterns, negations which never exclude anything,
+// repository configs shadowed by earlier repository configs, and insecure settings relaxing stricter ones.
+func (c *Config) Lint() []*Issue {
+	issues := lintTrust("trust", c.Trust)
+	issues = append(issues, lintInsecure("insecure", c.Insecure)...)
+	for i, repo := range c.Repositories {
+		p := fmt.Sprintf("repositories[%d]", i)
+		issues = append(issues, lintPatterns(p+".repositories", repo.Repositories, matcherKindRepo)...)
+		if j := c.shadowedBy(i); j >= 0 {
+			issues = append(issues, &Issue{
+				Path:    p,
+				Message: fmt.Sprintf("the repository config never takes effect because repositories[%d] matches the same repositories, takes precedence, and overrides all of its settings", j),
+			})
+		}
+		issues = append(issues, lintTrust(p+".trust", repo.Trust)...)
+		issues = append(issues, lintInsecure(p+".insecure", repo.Insecure)...)
+		issues = append(issues, lintInsecureOverride(p+".insecure", "root", c.Insecure, repo.Insecure)...)
+		base := overrideInsecure(c.Insecure, repo.Insecure)
+		for j, branch := range repo.Branches {
+			bp := fmt.Sprintf("%s.branches[%d]", p, j)
+			issues = append(issues, lintPatterns(bp+".branches", branch.Branches, matcherKindBranch)...)
+			issues = append(issues, lintTrust(bp+".trust", branch.Trust)...)
+			issues = append(issues, lintInsecure(bp+".insecure", branch.Insecure)...)
+			issues = append(issues, lintInsecureOverride(bp+".insecure", "repository", &base, branch.Insecure)...)
+		}
+	}
+	return issues
+}
+
+func lintTrust(path string, trust *Trust) []*Issue {
+	if trust == nil {
+		return nil
+	}
+	issues := lintPatterns(path+".trusted_apps", trust.TrustedApps, matcherKindApp)
+	issues = append(issues, lintPatterns(path+".untrusted_machine_users", trust.UntrustedMachineUsers, matcherKindUser)...)
+	issues = append(issues, lintDuplicates(path+".approver_teams", trust.ApproverTeams)...)
+	return append(issues, lintDuplicates(path+".approver_orgs", trust.ApproverOrgs)...)
+}
+
+func lintInsecure(path string, insecure *Insecure) []*Issue {
+	if insecure == nil {
+		return nil
+	}
+	issues := lintPatterns(path+".unsigned_commit_apps", insecure.UnsignedCommitApps, matcherKindApp)
+	return append(issues, lintPatterns(path+".unsigned_commit_machine_users", insecure.UnsignedCommitMachineUsers, matcherKindUser)...)
+}
+
+// lintPatterns reports duplicate patterns and negations which never exclude anything.
+// A negation only excludes names matched by preceding patterns, so it's useless if no preceding pattern can match names it matches.
+func lintPatterns(path string, patterns []string, kind matcherKind) []*Issue {
+	var issues []*Issue
+	seen := make(map[string]struct{}, len(patterns))
+	parsed := make([]*namePattern, 0, len(patterns))
+	for _, s := range patterns {
+		p, err := parsePattern(s, kind)
+		if err != nil {
+			// Invalid patterns are rejected when the config is initialized
+			continue
+		}
+		key := p.key()
+		if _, ok := seen[key]; ok {
+			issues = append(issues, &Issue{Path: path, Message: fmt.Sprintf("the pattern %q is duplicated", s)})
+		}
+		seen[key] = struct{}{}
+		if p.negate && !slices.ContainsFunc(parsed, func(prev *namePattern) bool {
+			return !prev.negate && prev.overlaps(p)
+		}) {
+			issues = append(issues, &Issue{Path: path, Message: fmt.Sprintf("the negation %q never excludes anything because no preceding pattern matches names it matches", s)})
+		}
+		parsed = append(parsed, p)
+	}
+	return issues
+}
+
+// lintDuplicates reports duplicate names. Names are compared case-insensitively.
+func lintDuplicates(path string, names []string) []*Issue {
+	var issues []*Issue
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if _, ok := seen[key]; ok {
+			issues = append(issues, &Issue{Path: path, Message: fmt.Sprintf("%q is duplicated", name)})
+		}
+		seen[key] = struct{}{}
+	}
+	return issues
+}
+
+// lintInsecureOverride reports insecure settings which allow unsigned commits the stricter base config doesn't allow.
+func lintInsecureOverride(path, baseName string, base, insecure *Insecure) []*Issue {
+	if insecure == nil {
+		return nil
+	}
+	if base == nil {
+		base = &Insecure{}
+	}
+	if base.AllowUnsignedCommits != nil && *base.AllowUnsignedCommits {
+		return nil
+	}
+	var issues []*Issue
+	if insecure.AllowUnsignedCommits != nil && *insecure.AllowUnsignedCommits {
+		issues = append(issues, &Issue{
+			Path:    path + ".allow_unsigned_commits",
+			Message: fmt.Sprintf("unsigned commits are allowed though the %s config doesn't allow them", baseName),
+		})
+	}
+	issues = append(issues, lintAddedPatterns(path+".unsigned_commit_apps", baseName, base.UnsignedCommitApps, insecure.UnsignedCommitApps, matcherKindApp)...)
+	return append(issues, lintAddedPatterns(path+".unsigned_commit_machine_users", baseName, base.UnsignedCommitMachineUsers, insecure.UnsignedCommitMachineUsers, matcherKindUser)...)
+}
+
+// lintAddedPatterns reports patterns which aren't in the base list.
+// Negations only make the list stricter, so they aren't reported.
+func lintAddedPatterns(path, baseName string, base, patterns []string, kind matcherKind) []*Issue {
+	keys := make(map[string]struct{}, len(base))
+	for _, s := range base {
+		if p, err := parsePattern(s, kind); err == nil {
+			keys[p.key()] = struct{}{}
+		}
+	}
+	var issues []*Issue
+	for _, s := range patterns {
+		p, err := parsePattern(s, kind)
+		if err != nil || p.negate {
+			continue
+		}
+		if _, ok := keys[p.key()]; !ok {
+			issues = append(issues, &Issue{
+				Path:    path,
+				Message: fmt.Sprintf("%q is allowed to push unsigned commits though the %s config doesn't allow it", s, baseName),
+			})
+		}
+	}
+	return issues
+}
+
+// key returns the normalized pattern to find duplicates.
+func (p *namePattern) key() string {
+	var s string
+	switch {
+	case p.re != nil:
+		s = "/" + p.re.String() + "/"
+	case p.team != "":
+		s = "@" + p.team
+	case p.glob != "":
+		s = p.glob
+	default:
+		s = p.exact
+	}
+	if p.negate {
+		return "!" + s
+	}
+	return s
+}
+
+// overlaps reports whether some names may match both patterns.
+// Regular expressions and team references are assumed to overlap with any pattern.
+func (p *namePattern) overlaps(other *namePattern) bool {
+	switch {
+	case p.re != nil || p.team != "" || other.re != nil || other.team != "":
+		return true
+	case p.exact != "":
+		return other.match(p.exact, nil)
+	case other.exact != "":
+		return p.match(other.exact, nil)
+	default:
+		// Globs overlap unless their literal prefixes differ
+		a, b := globPrefix(p.glob), globPrefix(other.glob)
+		return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+	}
+}
+
+// globPrefix returns characters of the glob before the first wildcard.
+func globPrefix(glob string) string {
+	if i := strings.IndexAny(glob, `*?[\`); i >= 0 {
+		return glob[:i]
+	}
+	return glob
+}
+
+// shadowedBy returns the index of an earlier repository config shadowing the i-th repository config, or -1.
+// A repository config is shadowed if an earlier config matches all repositories it matches,
+// takes precedence over it, and overrides all of its settings, so it never affects effective configs.
+// Repository configs with regular expressions aren't analyzed.
+func (c *Config) shadowedBy(i int) int {
+	repo := c.Repositories[i]
+	names := repo.patternNames()
+	if names == nil {
+		return -1
+	}
+	for j, prev := range c.Repositories[:i] {
+		if prev.shadows(repo, names) {
+			return j
+		}
+	}
+	return -1
+}
+
+// patternNames returns lower-cased repository name patterns except negations.
+// Globs are used as names, so a glob matching another glob as a name matches names it matches.
+// It returns nil if any pattern is a regular expression.
+func (r *Repository) patternNames() []string {
+	names := make([]string, 0, len(r.Repositories))
+	for _, s := range r.Repositories {
+		if strings.HasPrefix(s, "!") {
+			continue
+		}
+		if isRegexpPattern(s) {
+			return nil
+		}
+		names = append(names, strings.ToLower(s))
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	return names
+}
+
+func (r *Repository) shadows(other *Repository, names []string) bool {
+	if r.Selector != nil && !reflect.DeepEqual(r.Selector, other.Selector) {
+		return false
+	}
+	for _, name := range names {
+		if !r.precedes(other, name) {
+			return false
+		}
+	}
+	return r.ListMerge != ListMergeAppend && r.overrides(other)
+}
+
+// precedes reports whether the config matches the name and is merged after other in Config.GetRepo.
+func (r *Repository) precedes(other *Repository, name string) bool {
+	s := r.matcher.Specificity(name)
+	if s < 0 {
+		return false
+	}
+	if r.Priority != other.Priority {
+		return r.Priority > other.Priority
+	}
+	if o := other.matcher.Specificity(name); s != o {
+		return s > o
+	}
+	// The earlier config takes precedence if selectors are equally specific
+	return r.Selector.conditions() >= other.Selector.conditions()
+}
+
+// overrides reports whether the config sets all settings set by other.
+func (r *Repository) overrides(other *Repository) bool {
+	settings := r.settings()
+	for _, s := range other.settings() {
+		if !slices.Contains(settings, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// settings returns names of settings set in the config.
+// They follow Repository.merge, so unset settings are inherited.
+func (r *Repository) settings() []string {
+	var settings []string
+	add := func(name string, set bool) {
+		if set {
+			settings = append(settings, name)
+		}
+	}
+	if r.Trust != nil {
+		add("trust.trusted_apps", r.Trust.TrustedApps != nil)
+		add("trust.untrusted_machine_users", r.Trust.UntrustedMachineUsers != nil)
+		add("trust.approver_teams", r.Trust.ApproverTeams != nil)
+		add("trust.approver_orgs", r.Trust.ApproverOrgs != nil)
+	}
+	if i := r.Insecure; i != nil {
+		// allow_unsigned_commits: true clears the lists, and the lists set allow_unsigned_commits to false
+		allow := i.AllowUnsignedCommits != nil && *i.AllowUnsignedCommits
+		add("insecure.allow_unsigned_commits", i.AllowUnsignedCommits != nil || i.UnsignedCommitApps != nil || i.UnsignedCommitMachineUsers != nil)
+		add("insecure.unsigned_commit_apps", allow || i.UnsignedCommitApps != nil)
+		add("insecure.unsigned_commit_machine_users", allow || i.UnsignedCommitMachineUsers != nil)
+	}
+	add("ignored", r.Ignored != nil)
+	add("required_approvals", r.RequiredApprovals != 0)
+	add("required_approvals_with_untrusted_commits", r.RequiredApprovalsWithUntrustedCommits != 0)
+	add("require_code_owner_approvals", r.RequireCodeOwnerApprovals != nil)
+	add("sensitive_paths", r.SensitivePaths != nil)
+	add("required_approvals_for_sensitive_paths", r.RequiredApprovalsForSensitivePaths != 0)
+	add("block_on_changes_requested", r.BlockOnChangesRequested != nil)
+	add("signature_policy", r.SignaturePolicy != nil)
+	add("keyring", r.Keyring != nil)
+	add("gitsign", r.Gitsign != nil)
+	add("branches", r.Branches != nil)
+	add("repo_file", r.RepoFile != nil)
+	return settings
+}
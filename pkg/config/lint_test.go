@@ -0,0 +1,132 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+func TestConfig_Lint(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	tests := []struct {
+		name string
+		cfg  string
+		want []string
+	}{
+		{
+			name: "no issue",
+			cfg: `
+app_id: 1
+installation_id: 2
+trust:
+  untrusted_machine_users: ["*-bot", "!good-bot"]
+insecure:
+  unsigned_commit_apps: [renovate]
+repositories:
+  - repositories: [my-org/*]
+    trust: {}
+    required_approvals: 1
+  - repositories: [my-org/infra-*]
+    trust: {}
+    required_approvals: 2
+    insecure:
+      unsigned_commit_apps: [renovate, "!renovate"]
+`,
+		},
+		{
+			name: "duplicate patterns and useless negations",
+			cfg: `
+app_id: 1
+installation_id: 2
+trust:
+  trusted_apps: [renovate, "renovate[bot]"]
+  untrusted_machine_users: ["!foo", "bar-*", "!baz-*", "!/qux-.*/"]
+  approver_orgs: [my-org, My-Org]
+`,
+			want: []string{
+				`trust.trusted_apps: the pattern "renovate[bot]" is duplicated`,
+				`trust.untrusted_machine_users: the negation "!foo" never excludes anything because no preceding pattern matches names it matches`,
+				`trust.untrusted_machine_users: the negation "!baz-*" never excludes anything because no preceding pattern matches names it matches`,
+				`trust.approver_orgs: "My-Org" is duplicated`,
+			},
+		},
+		{
+			name: "shadowed repository config",
+			cfg: `
+app_id: 1
+installation_id: 2
+repositories:
+  - repositories: [my-org/*]
+    trust:
+      trusted_apps: [foo]
+    required_approvals: 2
+  - repositories: [my-org/*]
+    trust:
+      trusted_apps: [bar]
+  - repositories: [my-org/foo-*]
+    trust:
+      trusted_apps: [bar]
+  - repositories: [my-org/*]
+    trust: {}
+    required_approvals: 1
+    required_approvals_with_untrusted_commits: 2
+  - repositories: [my-org/baz]
+    trust:
+      trusted_apps: [baz]
+  - repositories: [my-org/*]
+    priority: -1
+    trust:
+      trusted_apps: [qux]
+    required_approvals: 2
+`,
+			want: []string{
+				"repositories[1]: the repository config never takes effect because repositories[0] matches the same repositories, takes precedence, and overrides all of its settings",
+				"repositories[5]: the repository config never takes effect because repositories[0] matches the same repositories, takes precedence, and overrides all of its settings",
+			},
+		},
+		{
+			name: "insecure settings relaxing stricter configs",
+			cfg: `
+app_id: 1
+installation_id: 2
+insecure:
+  unsigned_commit_apps: [renovate]
+repositories:
+  - repositories: [my-org/foo]
+    trust: {}
+    insecure:
+      allow_unsigned_commits: true
+  - repositories: [my-org/bar]
+    trust: {}
+    insecure:
+      unsigned_commit_apps: [renovate, dependabot]
+    branches:
+      - branches: [main]
+        insecure:
+          unsigned_commit_machine_users: [deploy-user]
+`,
+			want: []string{
+				"repositories[0].insecure.allow_unsigned_commits: unsigned commits are allowed though the root config doesn't allow them",
+				`repositories[1].insecure.unsigned_commit_apps: "dependabot" is allowed to push unsigned commits though the root config doesn't allow it`,
+				`repositories[1].branches[0].insecure.unsigned_commit_machine_users: "deploy-user" is allowed to push unsigned commits though the repository config doesn't allow it`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &config.Config{}
+			if err := config.Parse(cfg, []byte(tt.cfg)); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, issue := range cfg.Lint() {
+				got = append(got, issue.String())
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
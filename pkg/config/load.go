@@ -0,0 +1,318 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/invopop/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	keyInclude   = "include"
+	keyTemplates = "templates"
+)
+
+// envPattern matches ${NAME}, ${NAME:-default}, and the escaped form $${...}.
+var envPattern = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)(:-[^}]*)?\}`)
+
+// loader resolves includes, template files, and environment variables of config files.
+// Resolution is done on YAML nodes, so errors can report the file and line.
+type loader struct {
+	getEnv func(string) string
+	hash   hash.Hash
+	// files maps nodes to the names of files which define them
+	files map[*yaml.Node]string
+	// loading is the set of files being loaded to detect circular includes
+	loading map[string]struct{}
+}
+
+// newLoader returns a loader expanding environment variables with getEnv.
+func newLoader(getEnv func(string) string) *loader {
+	return &loader{
+		getEnv:  getEnv,
+		hash:    sha256.New(),
+		files:   map[*yaml.Node]string{},
+		loading: map[string]struct{}{},
+	}
+}
+
+// sum returns the SHA256 hash of all files read by the loader.
+// If the config doesn't include other files, it's equal to Hash of the config.
+func (l *loader) sum() string {
+	return hex.EncodeToString(l.hash.Sum(nil))
+}
+
+// loadFile reads and resolves the config file.
+func (l *loader) loadFile(path string) (*yaml.Node, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("get the absolute path of %s: %w", path, err)
+	}
+	if _, ok := l.loading[abs]; ok {
+		return nil, fmt.Errorf("%s is included circularly", path)
+	}
+	l.loading[abs] = struct{}{}
+	defer delete(l.loading, abs)
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	return l.load(path, filepath.Dir(path), data)
+}
+
+// load resolves the config and returns the root mapping node.
+// name is used in error messages.
+// Paths in the config are relative to dir. If dir is empty, the config can't refer to files.
+func (l *loader) load(name, dir string, data []byte) (*yaml.Node, error) {
+	l.hash.Write(data)
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+	if len(doc.Content) == 0 {
+		return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s:%d: the config must be a map", name, root.Line)
+	}
+	l.setFile(name, root)
+	for i := 1; i < len(root.Content); i += 2 {
+		if root.Content[i-1].Value == keyTemplates {
+			// Templates are kept literally because they're text such as shell snippets which may contain ${...}
+			continue
+		}
+		if err := l.expandEnv(name, root.Content[i]); err != nil {
+			return nil, err
+		}
+	}
+	includes, err := popInclude(name, root)
+	if err != nil {
+		return nil, err
+	}
+	if err := l.loadTemplateFiles(name, dir, root); err != nil {
+		return nil, err
+	}
+	for _, include := range includes {
+		if err := l.include(name, dir, root, include); err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+// setFile records the file which defines the node and its descendants.
+func (l *loader) setFile(name string, node *yaml.Node) {
+	l.files[node] = name
+	for _, child := range node.Content {
+		l.setFile(name, child)
+	}
+}
+
+// include merges files matching the pattern into root.
+func (l *loader) include(name, dir string, root, pattern *yaml.Node) error {
+	if dir == "" {
+		return fmt.Errorf("%s:%d: include isn't supported in this config", name, pattern.Line)
+	}
+	p := pattern.Value
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(dir, p)
+	}
+	paths, err := filepath.Glob(p)
+	if err != nil {
+		return fmt.Errorf("%s:%d: invalid include pattern %q: %w", name, pattern.Line, pattern.Value, err)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("%s:%d: no file matches the include pattern %q", name, pattern.Line, pattern.Value)
+	}
+	for _, path := range paths {
+		node, err := l.loadFile(path)
+		if err != nil {
+			return fmt.Errorf("%s:%d: include %s: %w", name, pattern.Line, path, err)
+		}
+		if err := mergeNode(path, root, node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// popInclude removes the include field from root and returns its patterns.
+func popInclude(name string, root *yaml.Node) ([]*yaml.Node, error) {
+	for i := 0; i < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+		if key.Value != keyInclude {
+			continue
+		}
+		root.Content = append(root.Content[:i], root.Content[i+2:]...)
+		if value.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("%s:%d: include must be a list of file paths or glob patterns", name, value.Line)
+		}
+		for _, p := range value.Content {
+			if p.Kind != yaml.ScalarNode || p.Value == "" {
+				return nil, fmt.Errorf("%s:%d: include must be a list of file paths or glob patterns", name, p.Line)
+			}
+		}
+		return value.Content, nil
+	}
+	return nil, nil
+}
+
+// loadTemplateFiles replaces templates like {file: approved.md} with the content of the file.
+func (l *loader) loadTemplateFiles(name, dir string, root *yaml.Node) error {
+	templates := mappingValue(root, keyTemplates)
+	if templates == nil || templates.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 1; i < len(templates.Content); i += 2 {
+		value := templates.Content[i]
+		if value.Kind != yaml.MappingNode {
+			continue
+		}
+		file := mappingValue(value, "file")
+		if file == nil || file.Kind != yaml.ScalarNode || len(value.Content) != 2 { //nolint:mnd
+			return fmt.Errorf("%s:%d: template must be a string or {file: <path>}", name, value.Line)
+		}
+		if dir == "" {
+			return fmt.Errorf("%s:%d: template files aren't supported in this config", name, file.Line)
+		}
+		p := file.Value
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(dir, p)
+		}
+		data, err := os.ReadFile(p) //nolint:gosec
+		if err != nil {
+			return fmt.Errorf("%s:%d: read a template file: %w", name, file.Line, err)
+		}
+		l.hash.Write(data)
+		templates.Content[i] = &yaml.Node{
+			Kind:   yaml.ScalarNode,
+			Tag:    "!!str",
+			Value:  string(data),
+			Line:   value.Line,
+			Column: value.Column,
+		}
+		l.files[templates.Content[i]] = name
+	}
+	return nil
+}
+
+// expandEnv expands environment variables in scalar values.
+// ${NAME} is replaced with the value of the environment variable NAME, and ${NAME:-default} falls back to default if NAME is unset or empty.
+// $${NAME} is replaced with ${NAME} literally.
+func (l *loader) expandEnv(name string, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		// Keys aren't expanded
+		for i := 1; i < len(node.Content); i += 2 {
+			if err := l.expandEnv(name, node.Content[i]); err != nil {
+				return err
+			}
+		}
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			if err := l.expandEnv(name, child); err != nil {
+				return err
+			}
+		}
+	case yaml.ScalarNode:
+		value, err := l.expandString(node.Value)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", name, node.Line, err)
+		}
+		if value == node.Value {
+			return nil
+		}
+		node.Value = value
+		if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			// Resolve the type from the expanded value so ${NUMBER} can be used for numbers
+			node.Tag = ""
+		}
+	default:
+	}
+	return nil
+}
+
+func (l *loader) expandString(s string) (string, error) {
+	var errs []error
+	value := envPattern.ReplaceAllStringFunc(s, func(m string) string {
+		if strings.HasPrefix(m, "$$") {
+			return m[1:]
+		}
+		sub := envPattern.FindStringSubmatch(m)
+		if v := l.getEnv(sub[1]); v != "" {
+			return v
+		}
+		if def, ok := strings.CutPrefix(sub[2], ":-"); ok {
+			return def
+		}
+		errs = append(errs, fmt.Errorf("the environment variable %s isn't set", sub[1]))
+		return m
+	})
+	return value, errors.Join(errs...)
+}
+
+// mergeNode merges the included mapping src into dst.
+// Lists are appended and maps are merged recursively.
+// Other fields can't be defined in multiple files.
+func mergeNode(name string, dst, src *yaml.Node) error {
+	for i := 0; i < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		current := mappingValue(dst, key.Value)
+		switch {
+		case current == nil:
+			dst.Content = append(dst.Content, key, value)
+		case current.Kind == yaml.SequenceNode && value.Kind == yaml.SequenceNode:
+			current.Content = append(current.Content, value.Content...)
+		case current.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			if err := mergeNode(name, current, value); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s:%d: %s is already defined", name, key.Line, key.Value)
+		}
+	}
+	return nil
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// JSONSchemaExtend allows templates to be loaded from files.
+// It has a value receiver because the JSON Schema generator checks the struct type.
+func (Config) JSONSchemaExtend(s *jsonschema.Schema) { //nolint:gocritic
+	templates, ok := s.Properties.Get("templates")
+	if !ok {
+		return
+	}
+	file := jsonschema.NewProperties()
+	file.Set("file", &jsonschema.Schema{
+		Type:        "string",
+		Description: "The path of the template file relative to the config file",
+	})
+	templates.AdditionalProperties = &jsonschema.Schema{
+		AnyOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{
+				Type:                 "object",
+				Properties:           file,
+				Required:             []string{"file"},
+				AdditionalProperties: jsonschema.FalseSchema,
+			},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestLoader_expandEnv(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		config  string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "expand",
+			config: "check_name: ${CHECK_NAME}",
+			want:   "check_name: validate-review\n",
+		},
+		{
+			name:   "default",
+			config: "required_approvals: ${REQUIRED_APPROVALS:-2}",
+			want:   "required_approvals: 2\n",
+		},
+		{
+			name:   "empty value uses the default",
+			config: "check_name: ${EMPTY:-default}",
+			want:   "check_name: default\n",
+		},
+		{
+			name:   "escape",
+			config: "check_name: $${CHECK_NAME}",
+			want:   "check_name: ${CHECK_NAME}\n",
+		},
+		{
+			name:   "keys aren't expanded",
+			config: "${CHECK_NAME}: foo",
+			want:   "${CHECK_NAME}: foo\n",
+		},
+		{
+			name:   "templates aren't expanded",
+			config: "templates:\n  approved: ${CHECK_NAME} $${CHECK_NAME}",
+			want:   "templates:\n    approved: ${CHECK_NAME} $${CHECK_NAME}\n",
+		},
+		{
+			name:    "undefined",
+			config:  "check_name: ${UNDEFINED}",
+			wantErr: true,
+		},
+	}
+	env := map[string]string{
+		"CHECK_NAME": "validate-review",
+		"EMPTY":      "",
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			l := newLoader(func(k string) string {
+				return env[k]
+			})
+			root, err := l.load("config.yaml", "", []byte(tt.config))
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatal(err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Fatal("error must be returned")
+			}
+			b, err := yaml.Marshal(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(b) != tt.want {
+				t.Errorf("got %q, want %q", string(b), tt.want)
+			}
+		})
+	}
+}
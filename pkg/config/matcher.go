@@ -0,0 +1,265 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Matcher matches names such as logins, apps, and repositories with a list of patterns.
+// Every list of names in the config is compiled into a Matcher, so all lists share the same syntax.
+//
+// Pattern syntax:
+//
+//   - `octocat`: an exact name
+//   - `bot-*`: a glob of path.Match
+//   - `/bot-[0-9]+/`: a regular expression enclosed in slashes. It's anchored, so it must match the whole name
+//   - `@org/team`: members of the team. Only lists of users support team references
+//   - `!pattern`: a negation. Names matching the pattern are excluded
+//
+// Names are matched case-insensitively.
+// Patterns are evaluated in order and the last matching pattern wins,
+// so a negation excludes names matched by preceding patterns and a later pattern can include them again.
+type Matcher struct {
+	kind     matcherKind
+	patterns []*namePattern
+	// members are lower-cased logins per lower-cased team reference such as org/team.
+	members map[string]map[string]struct{}
+}
+
+type matcherKind int
+
+const (
+	matcherKindUser matcherKind = iota
+	// apps are matched by names without the [bot] suffix.
+	matcherKindApp
+	matcherKindRepo
+	// branch names aren't restricted because git allows many characters.
+	matcherKindBranch
+)
+
+type namePattern struct {
+	negate bool
+	exact  string
+	glob   string
+	re     *regexp.Regexp
+	team   string
+}
+
+var (
+	userNameChars = regexp.MustCompile(`^[a-z0-9_\-*?\[\]]+$`)   //nolint:gochecknoglobals
+	repoNameChars = regexp.MustCompile(`^[a-z0-9_.\-/*?\[\]]+$`) //nolint:gochecknoglobals
+)
+
+// NewUserMatcher compiles patterns of users. Team references are allowed.
+func NewUserMatcher(patterns []string) (*Matcher, error) {
+	return newMatcher(patterns, matcherKindUser)
+}
+
+// NewAppMatcher compiles patterns of GitHub Apps.
+// The [bot] suffix of patterns and names is ignored, so both `renovate` and `renovate[bot]` match renovate[bot].
+func NewAppMatcher(patterns []string) (*Matcher, error) {
+	return newMatcher(patterns, matcherKindApp)
+}
+
+// NewRepoMatcher compiles patterns of repositories such as owner/repo.
+func NewRepoMatcher(patterns []string) (*Matcher, error) {
+	return newMatcher(patterns, matcherKindRepo)
+}
+
+// NewBranchMatcher compiles patterns of branches such as release/*.
+func NewBranchMatcher(patterns []string) (*Matcher, error) {
+	return newMatcher(patterns, matcherKindBranch)
+}
+
+func newMatcher(patterns []string, kind matcherKind) (*Matcher, error) {
+	m := &Matcher{
+		kind:     kind,
+		patterns: make([]*namePattern, 0, len(patterns)),
+	}
+	for _, p := range patterns {
+		pattern, err := parsePattern(p, kind)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		m.patterns = append(m.patterns, pattern)
+	}
+	return m, nil
+}
+
+func parsePattern(s string, kind matcherKind) (*namePattern, error) {
+	p := &namePattern{}
+	s, p.negate = strings.CutPrefix(s, "!")
+	if isRegexpPattern(s) {
+		re, err := regexp.Compile(`(?i)^(?:` + s[1:len(s)-1] + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("compile a regular expression: %w", err)
+		}
+		p.re = re
+		return p, nil
+	}
+	s = strings.ToLower(s)
+	if kind == matcherKindApp {
+		s = strings.TrimSuffix(s, "[bot]")
+	}
+	if s == "" {
+		return nil, errors.New("pattern is empty")
+	}
+	if team, ok := strings.CutPrefix(s, "@"); ok {
+		if kind != matcherKindUser {
+			return nil, errors.New("team references are allowed only in lists of users")
+		}
+		org, slug, ok := strings.Cut(team, "/")
+		if !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+			return nil, errors.New("team references must be in the format @<organization>/<team slug>")
+		}
+		p.team = team
+		return p, nil
+	}
+	chars := userNameChars
+	switch kind {
+	case matcherKindRepo:
+		chars = repoNameChars
+	case matcherKindBranch:
+		chars = nil
+	}
+	if chars != nil && !chars.MatchString(s) {
+		return nil, errors.New("the pattern contains an invalid character. Enclose regular expressions in slashes like /bot-[0-9]+/")
+	}
+	if !strings.ContainsAny(s, "*?[") {
+		p.exact = s
+		return p, nil
+	}
+	if _, err := path.Match(s, ""); err != nil {
+		return nil, fmt.Errorf("parse a glob: %w", err)
+	}
+	p.glob = s
+	return p, nil
+}
+
+// isRegexpPattern reports whether the pattern is a regular expression enclosed in slashes.
+func isRegexpPattern(s string) bool {
+	s = strings.TrimPrefix(s, "!")
+	return len(s) > 2 && strings.HasPrefix(s, "/") && strings.HasSuffix(s, "/")
+}
+
+func (p *namePattern) match(name string, members map[string]map[string]struct{}) bool {
+	switch {
+	case p.re != nil:
+		return p.re.MatchString(name)
+	case p.team != "":
+		_, ok := members[p.team][name]
+		return ok
+	case p.glob != "":
+		matched, err := path.Match(p.glob, name)
+		return err == nil && matched
+	default:
+		return p.exact == name
+	}
+}
+
+// Match reports whether the name matches the patterns.
+// A nil Matcher matches nothing.
+func (m *Matcher) Match(name string) bool {
+	if m == nil {
+		return false
+	}
+	name = strings.ToLower(name)
+	if m.kind == matcherKindApp {
+		name = strings.TrimSuffix(name, "[bot]")
+	}
+	matched := false
+	for _, p := range m.patterns {
+		if p.match(name, m.members) {
+			matched = !p.negate
+		}
+	}
+	return matched
+}
+
+// Specificity returns how specifically the patterns match the name, or -1 if the name doesn't match.
+// The last matching pattern decides the specificity like Match.
+// An exact name is the most specific, a glob is more specific as it has more literal characters,
+// and a regular expression is the least specific.
+func (m *Matcher) Specificity(name string) int {
+	if m == nil {
+		return -1
+	}
+	name = strings.ToLower(name)
+	if m.kind == matcherKindApp {
+		name = strings.TrimSuffix(name, "[bot]")
+	}
+	var matched *namePattern
+	for _, p := range m.patterns {
+		if p.match(name, m.members) {
+			matched = p
+		}
+	}
+	if matched == nil || matched.negate {
+		return -1
+	}
+	return matched.specificity()
+}
+
+func (p *namePattern) specificity() int {
+	switch {
+	case p.re != nil:
+		return 0
+	case p.glob != "":
+		return countGlobLiterals(p.glob)
+	default:
+		return math.MaxInt
+	}
+}
+
+// countGlobLiterals returns the number of characters which aren't wildcards or character classes.
+func countGlobLiterals(glob string) int {
+	n := 0
+	inClass := false
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; {
+		case inClass:
+			if c == ']' {
+				inClass = false
+			}
+		case c == '[':
+			inClass = true
+		case c == '\\':
+			i++
+			n++
+		case c != '*' && c != '?':
+			n++
+		}
+	}
+	return n
+}
+
+// Teams returns lower-cased team references such as org/team.
+func (m *Matcher) Teams() []string {
+	if m == nil {
+		return nil
+	}
+	var teams []string
+	for _, p := range m.patterns {
+		if p.team != "" {
+			teams = append(teams, p.team)
+		}
+	}
+	return teams
+}
+
+// WithTeamMembers returns a copy of the Matcher resolving team references with members.
+// members are lower-cased logins per lower-cased team reference such as org/team.
+// Team references not included in members match nobody.
+func (m *Matcher) WithTeamMembers(members map[string]map[string]struct{}) *Matcher {
+	if m == nil {
+		return nil
+	}
+	c := *m
+	c.members = maps.Clone(members)
+	return &c
+}
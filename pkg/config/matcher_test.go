@@ -0,0 +1,233 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+func TestMatcher_Match(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	members := map[string]map[string]struct{}{
+		"org/bots": {"ci-bot": {}},
+	}
+	tests := []struct {
+		name     string
+		new      func([]string) (*config.Matcher, error)
+		patterns []string
+		input    string
+		want     bool
+	}{
+		{
+			name:     "exact name ignoring case",
+			new:      config.NewUserMatcher,
+			patterns: []string{"Octocat"},
+			input:    "octocat",
+			want:     true,
+		},
+		{
+			name:     "glob",
+			new:      config.NewUserMatcher,
+			patterns: []string{"bot-*"},
+			input:    "bot-1",
+			want:     true,
+		},
+		{
+			name:     "regular expression is anchored",
+			new:      config.NewUserMatcher,
+			patterns: []string{"/bot-[0-9]+/"},
+			input:    "my-bot-1",
+		},
+		{
+			name:     "regular expression",
+			new:      config.NewUserMatcher,
+			patterns: []string{`/bot-\d+/`},
+			input:    "BOT-12",
+			want:     true,
+		},
+		{
+			name:     "team reference",
+			new:      config.NewUserMatcher,
+			patterns: []string{"@Org/Bots"},
+			input:    "ci-bot",
+			want:     true,
+		},
+		{
+			name:     "negation excludes preceding matches",
+			new:      config.NewUserMatcher,
+			patterns: []string{"*-bot", "!@org/bots"},
+			input:    "ci-bot",
+		},
+		{
+			name:     "the last matching pattern wins",
+			new:      config.NewUserMatcher,
+			patterns: []string{"*-bot", "!ci-*", "ci-bot"},
+			input:    "ci-bot",
+			want:     true,
+		},
+		{
+			name:     "negation alone matches nothing",
+			new:      config.NewUserMatcher,
+			patterns: []string{"!octocat"},
+			input:    "foo",
+		},
+		{
+			name:     "app without the [bot] suffix",
+			new:      config.NewAppMatcher,
+			patterns: []string{"renovate"},
+			input:    "renovate[bot]",
+			want:     true,
+		},
+		{
+			name:     "app glob",
+			new:      config.NewAppMatcher,
+			patterns: []string{"renovate*[bot]"},
+			input:    "renovate-approve[bot]",
+			want:     true,
+		},
+		{
+			name:     "repository glob with negation",
+			new:      config.NewRepoMatcher,
+			patterns: []string{"org/*", "!org/sandbox"},
+			input:    "org/sandbox",
+		},
+		{
+			name:     "repository with a dot",
+			new:      config.NewRepoMatcher,
+			patterns: []string{"org/foo.github.io"},
+			input:    "org/foo.github.io",
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := tt.new(tt.patterns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := m.WithTeamMembers(members).Match(tt.input); got != tt.want {
+				t.Errorf("Match(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewMatcher_Error(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		new      func([]string) (*config.Matcher, error)
+		patterns []string
+	}{
+		{
+			name:     "empty pattern",
+			new:      config.NewUserMatcher,
+			patterns: []string{""},
+		},
+		{
+			name:     "regular expression without slashes",
+			new:      config.NewUserMatcher,
+			patterns: []string{"bot-.*"},
+		},
+		{
+			name:     "invalid regular expression",
+			new:      config.NewUserMatcher,
+			patterns: []string{"/bot-(/"},
+		},
+		{
+			name:     "invalid glob",
+			new:      config.NewUserMatcher,
+			patterns: []string{"bot-[a"},
+		},
+		{
+			name:     "team reference without a team",
+			new:      config.NewUserMatcher,
+			patterns: []string{"@org"},
+		},
+		{
+			name:     "team reference of apps",
+			new:      config.NewAppMatcher,
+			patterns: []string{"@org/bots"},
+		},
+		{
+			name:     "team reference of repositories",
+			new:      config.NewRepoMatcher,
+			patterns: []string{"@org/bots"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := tt.new(tt.patterns); err == nil {
+				t.Error("an error should be returned")
+			}
+		})
+	}
+}
+
+func TestMatcher_Teams(t *testing.T) {
+	t.Parallel()
+	m, err := config.NewUserMatcher([]string{"octocat", "@Org/Bots", "!@org/admins"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"org/bots", "org/admins"}, m.Teams()); diff != "" {
+		t.Errorf("Teams() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestMatcher_Specificity(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		patterns []string
+		input    string
+		want     int
+	}{
+		{
+			name:     "glob counts literal characters",
+			patterns: []string{"org/infra-*"},
+			input:    "org/infra-prod",
+			want:     10,
+		},
+		{
+			name:     "character class isn't literal",
+			patterns: []string{"org/[ab]*"},
+			input:    "org/api",
+			want:     4,
+		},
+		{
+			name:     "regular expression is the least specific",
+			patterns: []string{"/org/.*/"},
+			input:    "org/infra",
+			want:     0,
+		},
+		{
+			name:     "not matched",
+			patterns: []string{"org/*", "!org/infra"},
+			input:    "org/infra",
+			want:     -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := config.NewRepoMatcher(tt.patterns)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := m.Specificity(tt.input); got != tt.want {
+				t.Errorf("Specificity() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+	m, err := config.NewRepoMatcher([]string{"org/*", "org/infra"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Specificity("org/infra") <= m.Specificity("org/other") {
+		t.Error("an exact name should be more specific than a glob")
+	}
+}
@@ -0,0 +1,221 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+// Policy is the validation policy of a repository.
+// The repository config is merged with the root config.
+type Policy struct {
+	Trust                     *Trust
+	Insecure                  *Insecure
+	Quorum                    *validation.Quorum
+	RequireCodeOwnerApprovals bool
+	SensitivePaths            []string
+	BlockOnChangesRequested   bool
+	SignaturePolicy           *validation.SignaturePolicy
+	Keyring                   *validation.Keyring
+	Gitsign                   validation.SignatureVerifier
+}
+
+// NewPolicy returns the policy for pull requests whose base branch is baseRef.
+// A branch rule of the repository config is merged with the repository config.
+func NewPolicy(cfg *Config, repo *Repository, baseRef string) (*Policy, error) {
+	var repoTrust *Trust
+	var repoInsecure *Insecure
+	var branch *BranchRule
+	if repo != nil {
+		repoTrust = repo.Trust
+		repoInsecure = repo.Insecure
+		branch = repo.GetBranchRule(baseRef)
+	}
+	trust := overrideTrust(cfg.Trust, repoTrust)
+	insecure := overrideInsecure(cfg.Insecure, repoInsecure)
+	if branch != nil {
+		trust = overrideTrust(&trust, branch.Trust)
+		insecure = overrideInsecure(&insecure, branch.Insecure)
+	}
+	if err := trust.Init(); err != nil {
+		return nil, fmt.Errorf("initialize the trust config: %w", err)
+	}
+	if err := insecure.Init(); err != nil {
+		return nil, fmt.Errorf("initialize the insecure config: %w", err)
+	}
+	p := &Policy{
+		Trust:                     &trust,
+		Insecure:                  &insecure,
+		Quorum:                    newQuorum(cfg, repo),
+		RequireCodeOwnerApprovals: cfg.RequireCodeOwnerApprovals,
+		SensitivePaths:            cfg.SensitivePaths,
+		BlockOnChangesRequested:   cfg.BlockOnChangesRequested,
+		SignaturePolicy:           newSignaturePolicy(cfg.SignaturePolicy),
+		Keyring:                   newKeyring(cfg.Keyring),
+		Gitsign:                   newGitsign(cfg.Gitsign),
+	}
+	if repo == nil {
+		return p, nil
+	}
+	if repo.RequireCodeOwnerApprovals != nil {
+		p.RequireCodeOwnerApprovals = *repo.RequireCodeOwnerApprovals
+	}
+	if repo.BlockOnChangesRequested != nil {
+		p.BlockOnChangesRequested = *repo.BlockOnChangesRequested
+	}
+	// Repository configs already fall back to the root config in Config.GetRepo.
+	p.SensitivePaths = repo.SensitivePaths
+	p.SignaturePolicy = newSignaturePolicy(repo.SignaturePolicy)
+	p.Keyring = newKeyring(repo.Keyring)
+	p.Gitsign = newGitsign(repo.Gitsign)
+	if branch == nil {
+		return p, nil
+	}
+	// Branch rules already fall back to the repository config in Config.GetRepo.
+	p.Quorum = &validation.Quorum{
+		RequiredApprovals:                     branch.RequiredApprovals,
+		RequiredApprovalsWithUntrustedCommits: branch.RequiredApprovalsWithUntrustedCommits,
+		RequiredApprovalsForSensitivePaths:    branch.RequiredApprovalsForSensitivePaths,
+	}
+	if branch.RequireCodeOwnerApprovals != nil {
+		p.RequireCodeOwnerApprovals = *branch.RequireCodeOwnerApprovals
+	}
+	if branch.BlockOnChangesRequested != nil {
+		p.BlockOnChangesRequested = *branch.BlockOnChangesRequested
+	}
+	return p, nil
+}
+
+// NewInput returns the input of validation for the pull request.
+// Team references in matchers aren't resolved, and approvers aren't restricted by approver teams and organizations.
+// Changed files aren't matched with code owners and sensitive paths.
+func (p *Policy) NewInput(pr *github.PullRequest) *validation.Input {
+	input := &validation.Input{
+		PR: pr,
+		Trust: &validation.Trust{
+			TrustedApps:           p.Trust.TrustedAppsMatcher,
+			UntrustedMachineUsers: p.Trust.UntrustedMachineUsersMatcher,
+		},
+		Quorum:                  p.Quorum,
+		BlockOnChangesRequested: p.BlockOnChangesRequested,
+		SignaturePolicy:         p.SignaturePolicy,
+		Keyring:                 p.Keyring,
+		Gitsign:                 p.Gitsign,
+	}
+	if p.Insecure != nil {
+		input.Insecure = &validation.Insecure{
+			AllowUnsignedCommits:       p.Insecure.AllowUnsignedCommits != nil && *p.Insecure.AllowUnsignedCommits,
+			UnsignedCommitApps:         p.Insecure.UnsignedCommitAppsMatcher,
+			UnsignedCommitMachineUsers: p.Insecure.UnsignedCommitMachineUsersMatcher,
+		}
+	}
+	return input
+}
+
+func newGitsign(cfg *Gitsign) validation.SignatureVerifier {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Built
+}
+
+func newKeyring(cfg *Keyring) *validation.Keyring {
+	if cfg == nil {
+		return nil
+	}
+	return &validation.Keyring{
+		Verifier: cfg.Built,
+		Replace:  cfg.Mode == KeyringModeReplace,
+	}
+}
+
+func newSignaturePolicy(cfg *SignaturePolicy) *validation.SignaturePolicy {
+	if cfg == nil {
+		return nil
+	}
+	allowedKeys := make(map[string][]string, len(cfg.AllowedKeys))
+	for login, keys := range cfg.AllowedKeys {
+		allowedKeys[strings.ToLower(login)] = keys
+	}
+	return &validation.SignaturePolicy{
+		AcceptedStates: toSet(cfg.AcceptedStates),
+		Types:          toSet(cfg.Types),
+		AllowedKeys:    allowedKeys,
+	}
+}
+
+func overrideTrust(global *Trust, repo *Trust) Trust {
+	var trust Trust
+	if global != nil {
+		trust = *global
+	}
+	if repo != nil {
+		if repo.TrustedApps != nil {
+			trust.TrustedApps = repo.TrustedApps
+		}
+		if repo.UntrustedMachineUsers != nil {
+			trust.UntrustedMachineUsers = repo.UntrustedMachineUsers
+		}
+		if repo.ApproverTeams != nil {
+			trust.ApproverTeams = repo.ApproverTeams
+		}
+		if repo.ApproverOrgs != nil {
+			trust.ApproverOrgs = repo.ApproverOrgs
+		}
+	}
+	return trust
+}
+
+func overrideInsecure(global *Insecure, repo *Insecure) Insecure {
+	var insecure Insecure
+	if global != nil {
+		insecure = *global
+	}
+	if repo == nil {
+		return insecure
+	}
+	if repo.UnsignedCommitApps != nil {
+		insecure.UnsignedCommitApps = repo.UnsignedCommitApps
+		insecure.AllowUnsignedCommits = new(false)
+	}
+	if repo.UnsignedCommitMachineUsers != nil {
+		insecure.UnsignedCommitMachineUsers = repo.UnsignedCommitMachineUsers
+		insecure.AllowUnsignedCommits = new(false)
+	}
+	if repo.AllowUnsignedCommits != nil {
+		insecure.AllowUnsignedCommits = repo.AllowUnsignedCommits
+		if *repo.AllowUnsignedCommits {
+			// If repo.AllowUnsignedCommits is true, it overrides global UnsignedCommitApps and UnsignedCommitMachineUsers.
+			insecure.UnsignedCommitApps = nil
+			insecure.UnsignedCommitMachineUsers = nil
+		}
+	}
+	return insecure
+}
+
+// newQuorum returns the number of required approvals.
+// Repository configs already fall back to the root config in Config.GetRepo.
+func newQuorum(cfg *Config, repo *Repository) *validation.Quorum {
+	if repo != nil {
+		return &validation.Quorum{
+			RequiredApprovals:                     repo.RequiredApprovals,
+			RequiredApprovalsWithUntrustedCommits: repo.RequiredApprovalsWithUntrustedCommits,
+			RequiredApprovalsForSensitivePaths:    repo.RequiredApprovalsForSensitivePaths,
+		}
+	}
+	return &validation.Quorum{
+		RequiredApprovals:                     cfg.RequiredApprovals,
+		RequiredApprovalsWithUntrustedCommits: cfg.RequiredApprovalsWithUntrustedCommits,
+		RequiredApprovalsForSensitivePaths:    cfg.RequiredApprovalsForSensitivePaths,
+	}
+}
+
+func toSet(s []string) map[string]struct{} {
+	m := make(map[string]struct{}, len(s))
+	for _, v := range s {
+		m[v] = struct{}{}
+	}
+	return m
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+func TestNewPolicy_branchRule(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	cfg := &Config{
+		Repositories: []*Repository{
+			{
+				Repositories: []string{"org/repo"},
+				Trust: &Trust{
+					UntrustedMachineUsers: []string{"*-bot"},
+				},
+				RequiredApprovals: 1,
+				Branches: []*BranchRule{
+					{
+						Branches: []string{"release/*", "main"},
+						Trust: &Trust{
+							TrustedApps: []string{"renovate"},
+						},
+						RequiredApprovals:         2,
+						RequireCodeOwnerApprovals: new(true),
+					},
+				},
+			},
+		},
+	}
+	if err := cfg.Init(); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := cfg.GetRepo(&github.Repository{FullName: "org/repo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name                  string
+		baseRef               string
+		wantQuorum            *validation.Quorum
+		wantTrustedApps       []string
+		wantRequireCodeOwners bool
+	}{
+		{
+			name:    "branch rule",
+			baseRef: "release/v1",
+			wantQuorum: &validation.Quorum{
+				RequiredApprovals:                     2,
+				RequiredApprovalsWithUntrustedCommits: 3,
+				RequiredApprovalsForSensitivePaths:    3,
+			},
+			wantTrustedApps:       []string{"renovate[bot]"},
+			wantRequireCodeOwners: true,
+		},
+		{
+			name:    "no branch rule matches",
+			baseRef: "feature/foo",
+			wantQuorum: &validation.Quorum{
+				RequiredApprovals:                     1,
+				RequiredApprovalsWithUntrustedCommits: 2,
+				RequiredApprovalsForSensitivePaths:    2,
+			},
+			wantTrustedApps: []string{"dependabot[bot]", "renovate[bot]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := NewPolicy(cfg, repo, tt.baseRef)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantQuorum, p.Quorum); diff != "" {
+				t.Errorf("quorum mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantTrustedApps, p.Trust.TrustedApps); diff != "" {
+				t.Errorf("trusted apps mismatch (-want +got):\n%s", diff)
+			}
+			if p.RequireCodeOwnerApprovals != tt.wantRequireCodeOwners {
+				t.Errorf("requireCodeOwnerApprovals = %v, want %v", p.RequireCodeOwnerApprovals, tt.wantRequireCodeOwners)
+			}
+			// untrusted machine users of the repository config are inherited
+			if !p.Trust.UntrustedMachineUsersMatcher.Match("ci-bot") {
+				t.Error("ci-bot should be an untrusted machine user")
+			}
+		})
+	}
+}
@@ -1,32 +1,32 @@
-package controller
+package config
 
 import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
 )
 
-func Test_mergeTrust(t *testing.T) { //nolint:funlen
+func Test_overrideTrust(t *testing.T) { //nolint:funlen
 	t.Parallel()
 	tests := []struct {
 		name   string
-		global *config.Trust
-		repo   *config.Trust
-		want   config.Trust
+		global *Trust
+		repo   *Trust
+		want   Trust
 	}{
 		{
 			name: "both nil",
-			want: config.Trust{},
+			want: Trust{},
 		},
 		{
 			name: "global set, repo nil",
-			global: &config.Trust{
+			global: &Trust{
 				TrustedApps: []string{"app1[bot]"},
 
 				UntrustedMachineUsers: []string{"evil*"},
 			},
-			want: config.Trust{
+			want: Trust{
 				TrustedApps: []string{"app1[bot]"},
 
 				UntrustedMachineUsers: []string{"evil*"},
@@ -34,15 +34,15 @@ func Test_mergeTrust(t *testing.T) { //nolint:funlen
 		},
 		{
 			name: "global set, repo partial override",
-			global: &config.Trust{
+			global: &Trust{
 				TrustedApps: []string{"app1[bot]"},
 
 				UntrustedMachineUsers: []string{"evil*"},
 			},
-			repo: &config.Trust{
+			repo: &Trust{
 				TrustedApps: []string{"app2[bot]"},
 			},
-			want: config.Trust{
+			want: Trust{
 				TrustedApps: []string{"app2[bot]"},
 
 				UntrustedMachineUsers: []string{"evil*"},
@@ -50,16 +50,16 @@ func Test_mergeTrust(t *testing.T) { //nolint:funlen
 		},
 		{
 			name: "global set, repo full override",
-			global: &config.Trust{
+			global: &Trust{
 				TrustedApps: []string{"app1[bot]"},
 
 				UntrustedMachineUsers: []string{"evil*"},
 			},
-			repo: &config.Trust{
+			repo: &Trust{
 				TrustedApps:           []string{"app2[bot]"},
 				UntrustedMachineUsers: []string{"bad*"},
 			},
-			want: config.Trust{
+			want: Trust{
 				TrustedApps:           []string{"app2[bot]"},
 				UntrustedMachineUsers: []string{"bad*"},
 			},
@@ -67,10 +67,10 @@ func Test_mergeTrust(t *testing.T) { //nolint:funlen
 		{
 			name:   "global nil, repo set",
 			global: nil,
-			repo: &config.Trust{
+			repo: &Trust{
 				TrustedApps: []string{"app2[bot]"},
 			},
-			want: config.Trust{
+			want: Trust{
 				TrustedApps: []string{"app2[bot]"},
 			},
 		},
@@ -78,75 +78,75 @@ func Test_mergeTrust(t *testing.T) { //nolint:funlen
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := mergeTrust(tt.global, tt.repo)
+			got := overrideTrust(tt.global, tt.repo)
 			if diff := cmp.Diff(tt.want, got); diff != "" {
-				t.Errorf("mergeTrust() mismatch (-want +got):\n%s", diff)
+				t.Errorf("overrideTrust() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func Test_mergeTrust_doesNotMutateGlobal(t *testing.T) {
+func Test_overrideTrust_doesNotMutateGlobal(t *testing.T) {
 	t.Parallel()
-	global := &config.Trust{
+	global := &Trust{
 		TrustedApps: []string{"app1[bot]"},
 
 		UntrustedMachineUsers: []string{"evil*"},
 	}
-	repo := &config.Trust{
+	repo := &Trust{
 		TrustedApps: []string{"app2[bot]"},
 	}
 	original := *global
-	_ = mergeTrust(global, repo)
+	_ = overrideTrust(global, repo)
 	if diff := cmp.Diff(original, *global); diff != "" {
-		t.Errorf("mergeTrust mutated global (-before +after):\n%s", diff)
+		t.Errorf("overrideTrust mutated global (-before +after):\n%s", diff)
 	}
 }
 
-func Test_mergeInsecure(t *testing.T) { //nolint:funlen
+func Test_overrideInsecure(t *testing.T) { //nolint:funlen
 	t.Parallel()
 	tests := []struct {
 		name   string
-		global *config.Insecure
-		repo   *config.Insecure
-		want   config.Insecure
+		global *Insecure
+		repo   *Insecure
+		want   Insecure
 	}{
 		{
 			name: "both nil",
-			want: config.Insecure{},
+			want: Insecure{},
 		},
 		{
 			name: "global set AllowUnsignedCommits true, repo nil",
-			global: &config.Insecure{
+			global: &Insecure{
 				AllowUnsignedCommits: new(true),
 			},
-			want: config.Insecure{
+			want: Insecure{
 				AllowUnsignedCommits: new(true),
 			},
 		},
 		{
 			name: "global set apps and machine users, repo nil",
-			global: &config.Insecure{
+			global: &Insecure{
 				UnsignedCommitApps:         []string{"app1"},
 				UnsignedCommitMachineUsers: []string{"bot1"},
 			},
-			want: config.Insecure{
+			want: Insecure{
 				UnsignedCommitApps:         []string{"app1"},
 				UnsignedCommitMachineUsers: []string{"bot1"},
 			},
 		},
 		{
 			name: "global set apps and machine users, repo overrides all",
-			global: &config.Insecure{
+			global: &Insecure{
 				UnsignedCommitApps:         []string{"app1"},
 				UnsignedCommitMachineUsers: []string{"bot1"},
 			},
-			repo: &config.Insecure{
+			repo: &Insecure{
 				AllowUnsignedCommits:       new(false),
 				UnsignedCommitApps:         []string{"app2"},
 				UnsignedCommitMachineUsers: []string{"bot2"},
 			},
-			want: config.Insecure{
+			want: Insecure{
 				AllowUnsignedCommits:       new(false),
 				UnsignedCommitApps:         []string{"app2"},
 				UnsignedCommitMachineUsers: []string{"bot2"},
@@ -154,14 +154,14 @@ func Test_mergeInsecure(t *testing.T) { //nolint:funlen
 		},
 		{
 			name: "global set apps and machine users, repo partial override apps only",
-			global: &config.Insecure{
+			global: &Insecure{
 				UnsignedCommitApps:         []string{"app1"},
 				UnsignedCommitMachineUsers: []string{"bot1"},
 			},
-			repo: &config.Insecure{
+			repo: &Insecure{
 				UnsignedCommitApps: []string{"app2"},
 			},
-			want: config.Insecure{
+			want: Insecure{
 				AllowUnsignedCommits:       new(false),
 				UnsignedCommitApps:         []string{"app2"},
 				UnsignedCommitMachineUsers: []string{"bot1"},
@@ -169,40 +169,40 @@ func Test_mergeInsecure(t *testing.T) { //nolint:funlen
 		},
 		{
 			name: "global set AllowUnsignedCommits true, repo sets apps",
-			global: &config.Insecure{
+			global: &Insecure{
 				AllowUnsignedCommits: new(true),
 			},
-			repo: &config.Insecure{
+			repo: &Insecure{
 				UnsignedCommitApps: []string{"app2"},
 			},
-			want: config.Insecure{
+			want: Insecure{
 				AllowUnsignedCommits: new(false),
 				UnsignedCommitApps:   []string{"app2"},
 			},
 		},
 		{
 			name: "global set apps, repo sets AllowUnsignedCommits true",
-			global: &config.Insecure{
+			global: &Insecure{
 				UnsignedCommitApps:         []string{"app1"},
 				UnsignedCommitMachineUsers: []string{"bot1"},
 			},
-			repo: &config.Insecure{
+			repo: &Insecure{
 				AllowUnsignedCommits: new(true),
 			},
-			want: config.Insecure{
+			want: Insecure{
 				AllowUnsignedCommits: new(true),
 			},
 		},
 		{
 			name: "global set apps and machine users, repo sets machine users only",
-			global: &config.Insecure{
+			global: &Insecure{
 				UnsignedCommitApps:         []string{"app1"},
 				UnsignedCommitMachineUsers: []string{"bot1"},
 			},
-			repo: &config.Insecure{
+			repo: &Insecure{
 				UnsignedCommitMachineUsers: []string{"bot2"},
 			},
-			want: config.Insecure{
+			want: Insecure{
 				AllowUnsignedCommits:       new(false),
 				UnsignedCommitApps:         []string{"app1"},
 				UnsignedCommitMachineUsers: []string{"bot2"},
@@ -211,10 +211,10 @@ func Test_mergeInsecure(t *testing.T) { //nolint:funlen
 		{
 			name:   "global nil, repo set",
 			global: nil,
-			repo: &config.Insecure{
+			repo: &Insecure{
 				AllowUnsignedCommits: new(true),
 			},
-			want: config.Insecure{
+			want: Insecure{
 				AllowUnsignedCommits: new(true),
 			},
 		},
@@ -222,28 +222,73 @@ func Test_mergeInsecure(t *testing.T) { //nolint:funlen
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := mergeInsecure(tt.global, tt.repo)
+			got := overrideInsecure(tt.global, tt.repo)
 			if diff := cmp.Diff(tt.want, got); diff != "" {
-				t.Errorf("mergeInsecure() mismatch (-want +got):\n%s", diff)
+				t.Errorf("overrideInsecure() mismatch (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func Test_mergeInsecure_doesNotMutateGlobal(t *testing.T) {
+func Test_overrideInsecure_doesNotMutateGlobal(t *testing.T) {
 	t.Parallel()
-	global := &config.Insecure{
+	global := &Insecure{
 		UnsignedCommitApps:         []string{"app1"},
 		UnsignedCommitMachineUsers: []string{"bot1"},
 	}
-	repo := &config.Insecure{
+	repo := &Insecure{
 		AllowUnsignedCommits:       new(false),
 		UnsignedCommitApps:         []string{"app2"},
 		UnsignedCommitMachineUsers: []string{"bot2"},
 	}
 	original := *global
-	_ = mergeInsecure(global, repo)
+	_ = overrideInsecure(global, repo)
 	if diff := cmp.Diff(original, *global); diff != "" {
-		t.Errorf("mergeInsecure mutated global (-before +after):\n%s", diff)
+		t.Errorf("overrideInsecure mutated global (-before +after):\n%s", diff)
+	}
+}
+
+func Test_newQuorum(t *testing.T) {
+	t.Parallel()
+	cfg := &Config{
+		RequiredApprovals:                     2,
+		RequiredApprovalsWithUntrustedCommits: 3,
+		RequiredApprovalsForSensitivePaths:    3,
+	}
+	tests := []struct {
+		name string
+		repo *Repository
+		want *validation.Quorum
+	}{
+		{
+			name: "no repository config",
+			want: &validation.Quorum{
+				RequiredApprovals:                     2,
+				RequiredApprovalsWithUntrustedCommits: 3,
+				RequiredApprovalsForSensitivePaths:    3,
+			},
+		},
+		{
+			name: "repository config",
+			repo: &Repository{
+				RequiredApprovals:                     1,
+				RequiredApprovalsWithUntrustedCommits: 1,
+				RequiredApprovalsForSensitivePaths:    2,
+			},
+			want: &validation.Quorum{
+				RequiredApprovals:                     1,
+				RequiredApprovalsWithUntrustedCommits: 1,
+				RequiredApprovalsForSensitivePaths:    2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := newQuorum(cfg, tt.repo)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("newQuorum() mismatch (-want +got):\n%s", diff)
+			}
+		})
 	}
 }
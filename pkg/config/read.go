@@ -1,7 +1,9 @@
 package config
 
 import (
-	"errors"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 
@@ -9,30 +11,56 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// Read reads the config from the environment variable CONFIG or the file CONFIG_FILE.
 func Read(cfg *Config) error {
-	cfgBytes, err := readConfigBytes()
+	return NewSource(nil, nil, os.Getenv).Read(context.Background(), cfg)
+}
+
+// Parse parses and initializes the config.
+// Paths of included files and template files are relative to the working directory.
+// Environment variables are expanded with environment variables of the process.
+// Hash of the config is set.
+func Parse(cfg *Config, cfgBytes []byte) error {
+	return parse(cfg, newLoader(os.Getenv), "CONFIG", ".", cfgBytes)
+}
+
+// ParseFile reads, parses, and initializes the config file.
+// Paths of included files and template files are relative to the directory of the file.
+// Environment variables are expanded with getEnv.
+func ParseFile(cfg *Config, path string, getEnv func(string) string) error {
+	l := newLoader(getEnv)
+	root, err := l.loadFile(path)
+	if err != nil {
+		return fmt.Errorf("load the config: %w", slogerr.With(err, "config_file", path))
+	}
+	return decode(cfg, l, root)
+}
+
+func parse(cfg *Config, l *loader, name, dir string, cfgBytes []byte) error {
+	root, err := l.load(name, dir, cfgBytes)
 	if err != nil {
 		return err
 	}
-	if err := yaml.Unmarshal(cfgBytes, cfg); err != nil {
-		return fmt.Errorf("failed to parse CONFIG environment variable: %w", err)
+	return decode(cfg, l, root)
+}
+
+// decode validates the resolved config against the JSON Schema, decodes, and initializes it.
+func decode(cfg *Config, l *loader, root *yaml.Node) error {
+	if err := l.validateSchema(root); err != nil {
+		return err
+	}
+	if err := root.Decode(cfg); err != nil {
+		return fmt.Errorf("parse the config: %w", err)
 	}
 	if err := cfg.Init(); err != nil {
 		return fmt.Errorf("initialize config: %w", err)
 	}
+	cfg.Hash = l.sum()
 	return nil
 }
 
-func readConfigBytes() ([]byte, error) {
-	if cfgStr := os.Getenv("CONFIG"); cfgStr != "" {
-		return []byte(cfgStr), nil
-	}
-	if cfgPath := os.Getenv("CONFIG_FILE"); cfgPath != "" {
-		data, err := os.ReadFile(cfgPath) //nolint:gosec
-		if err != nil {
-			return nil, fmt.Errorf("read config file: %w", slogerr.With(err, "config_file", cfgPath))
-		}
-		return data, nil
-	}
-	return nil, errors.New("CONFIG or CONFIG_FILE environment variable is required")
+// Hash returns the SHA256 hash of the raw config to identify the active config.
+func Hash(cfgBytes []byte) string {
+	h := sha256.Sum256(cfgBytes)
+	return hex.EncodeToString(h[:])
 }
@@ -0,0 +1,234 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+func TestParseFile(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	tests := []struct {
+		name             string
+		files            map[string]string
+		wantRepositories []string
+		wantTemplate     string
+		wantCheckName    string
+		wantErr          string
+	}{
+		{
+			name: "include",
+			files: map[string]string{
+				"config.yaml": `
+app_id: 1
+installation_id: 2
+include:
+  - repos/*.yaml
+repositories:
+  - repositories: [suzuki-shunsuke/main]
+    trust: {}
+`,
+				"repos/a.yaml": `
+repositories:
+  - repositories: [suzuki-shunsuke/a]
+    trust: {}
+`,
+				"repos/b.yaml": `
+include:
+  - ../common.yaml
+repositories:
+  - repositories: [suzuki-shunsuke/b]
+    trust: {}
+`,
+				"common.yaml": `
+repositories:
+  - repositories: [suzuki-shunsuke/common]
+    trust: {}
+`,
+			},
+			wantRepositories: []string{"suzuki-shunsuke/main", "suzuki-shunsuke/a", "suzuki-shunsuke/b", "suzuki-shunsuke/common"},
+		},
+		{
+			name: "template file",
+			files: map[string]string{
+				"config.yaml": `
+app_id: 1
+installation_id: 2
+include: [templates.yaml]
+`,
+				"templates.yaml": `
+templates:
+  approved:
+    file: templates/approved.md
+`,
+				"templates/approved.md": "Approved",
+			},
+			wantTemplate: "Approved",
+		},
+		{
+			name: "no file matches",
+			files: map[string]string{
+				"config.yaml": `
+app_id: 1
+include:
+  - repos/*.yaml
+`,
+			},
+			wantErr: "config.yaml:4: no file matches",
+		},
+		{
+			name: "duplicated field",
+			files: map[string]string{
+				"config.yaml": `
+app_id: 1
+include: [other.yaml]
+`,
+				"other.yaml": `
+required_approvals: 1
+app_id: 1
+`,
+			},
+			wantErr: "other.yaml:3: app_id is already defined",
+		},
+		{
+			name: "circular include",
+			files: map[string]string{
+				"config.yaml": `
+include: [other.yaml]
+`,
+				"other.yaml": `
+include: [config.yaml]
+`,
+			},
+			wantErr: "included circularly",
+		},
+		{
+			name: "invalid type in an included file",
+			files: map[string]string{
+				"config.yaml": `
+app_id: 1
+installation_id: 2
+include: [other.yaml]
+`,
+				"other.yaml": `
+required_approvals: foo
+`,
+			},
+			wantErr: "other.yaml:2:21: required_approvals: got string, want integer",
+		},
+		{
+			name: "unknown field",
+			files: map[string]string{
+				"config.yaml": `
+app_id: 1
+installation_id: 2
+insecur:
+  allow_unsigned_commits: true
+`,
+			},
+			wantErr: "config.yaml:4:1: insecur: unknown field",
+		},
+		{
+			name: "unknown field in an included repository config",
+			files: map[string]string{
+				"config.yaml": `
+app_id: 1
+installation_id: 2
+include: [repos.yaml]
+`,
+				"repos.yaml": `
+repositories:
+  - repositories: [suzuki-shunsuke/a]
+    trust:
+      trusted_app: [renovate]
+`,
+			},
+			wantErr: "repos.yaml:5:7: repositories[0].trust.trusted_app: unknown field",
+		},
+		{
+			name: "null fields are ignored",
+			files: map[string]string{
+				"config.yaml": `
+app_id: 1
+installation_id: 2
+trust:
+templates:
+`,
+			},
+		},
+		{
+			name: "environment variables are read with getEnv",
+			files: map[string]string{
+				"config.yaml": `
+app_id: 1
+installation_id: 2
+check_name: ${VALIDATE_PR_REVIEW_APP_CHECK_NAME}
+`,
+			},
+			wantCheckName: "injected-check",
+		},
+		{
+			name: "undefined environment variable",
+			files: map[string]string{
+				"config.yaml": `
+app_id: 1
+check_name: ${VALIDATE_PR_REVIEW_APP_UNDEFINED_ENV}
+`,
+			},
+			wantErr: "config.yaml:3: the environment variable VALIDATE_PR_REVIEW_APP_UNDEFINED_ENV isn't set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				p := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cfg := &config.Config{}
+			err := config.ParseFile(cfg, filepath.Join(dir, "config.yaml"), func(k string) string {
+				return map[string]string{"VALIDATE_PR_REVIEW_APP_CHECK_NAME": "injected-check"}[k]
+			})
+			if err != nil {
+				if tt.wantErr == "" {
+					t.Fatal(err)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error must contain %q: %v", tt.wantErr, err)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatal("error must be returned")
+			}
+			if tt.wantRepositories != nil {
+				var repos []string
+				for _, r := range cfg.Repositories {
+					repos = append(repos, r.Repositories...)
+				}
+				if diff := cmp.Diff(tt.wantRepositories, repos); diff != "" {
+					t.Errorf("repositories mismatch (-want +got):\n%s", diff)
+				}
+			}
+			if tt.wantCheckName != "" && cfg.CheckName != tt.wantCheckName {
+				t.Errorf("check_name = %q, want %q", cfg.CheckName, tt.wantCheckName)
+			}
+			if tt.wantTemplate != "" && cfg.Templates["approved"] != tt.wantTemplate {
+				t.Errorf("template = %q, want %q", cfg.Templates["approved"], tt.wantTemplate)
+			}
+			if len(tt.files) > 1 && cfg.Hash == config.Hash([]byte(tt.files["config.yaml"])) {
+				t.Error("the hash must include included files")
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RepoFilePath is the path of the config file in repositories.
+// It's read from the default branch, so pull requests can't change their own policies.
+const RepoFilePath = ".github/validate-pr-review.yaml"
+
+// Fields of the repository config file.
+const (
+	repoFieldTrustedApps                           = "trust.trusted_apps"
+	repoFieldUntrustedMachineUsers                 = "trust.untrusted_machine_users"
+	repoFieldApproverTeams                         = "trust.approver_teams"
+	repoFieldApproverOrgs                          = "trust.approver_orgs"
+	repoFieldRequiredApprovals                     = "required_approvals"
+	repoFieldRequiredApprovalsWithUntrustedCommits = "required_approvals_with_untrusted_commits"
+	repoFieldRequiredApprovalsForSensitivePaths    = "required_approvals_for_sensitive_paths"
+	repoFieldRequireCodeOwnerApprovals             = "require_code_owner_approvals"
+	repoFieldBlockOnChangesRequested               = "block_on_changes_requested"
+	repoFieldSensitivePaths                        = "sensitive_paths"
+)
+
+// repoFileFields reports whether each field of the repository config file can be made stricter.
+// Approver teams and organizations can't, because adding them may allow more approvers.
+var repoFileFields = map[string]bool{ //nolint:gochecknoglobals
+	repoFieldTrustedApps:                           true,
+	repoFieldUntrustedMachineUsers:                 true,
+	repoFieldApproverTeams:                         false,
+	repoFieldApproverOrgs:                          false,
+	repoFieldRequiredApprovals:                     true,
+	repoFieldRequiredApprovalsWithUntrustedCommits: true,
+	repoFieldRequiredApprovalsForSensitivePaths:    true,
+	repoFieldRequireCodeOwnerApprovals:             true,
+	repoFieldBlockOnChangesRequested:               true,
+	repoFieldSensitivePaths:                        true,
+}
+
+// RepoFilePolicy decides which fields the repository config file can change.
+// If it isn't set, the repository config file isn't read.
+type RepoFilePolicy struct {
+	// Overridable fields can be changed freely
+	Overridable []string `json:"overridable,omitempty" yaml:"overridable"`
+	// Stricter fields can only be made stricter.
+	// Trusted apps can only be removed, untrusted machine users and sensitive paths are appended,
+	// the numbers of required approvals can only be increased, and booleans can only be enabled.
+	Stricter []string `json:"stricter,omitempty" yaml:"stricter"`
+}
+
+func (p *RepoFilePolicy) Validate() error {
+	for _, field := range p.Overridable {
+		if _, ok := repoFileFields[field]; !ok {
+			return fmt.Errorf("overridable: unknown field %q", field)
+		}
+	}
+	for _, field := range p.Stricter {
+		stricter, ok := repoFileFields[field]
+		if !ok {
+			return fmt.Errorf("stricter: unknown field %q", field)
+		}
+		if !stricter {
+			return fmt.Errorf("stricter: %s can't be made stricter. Add it to overridable", field)
+		}
+		if slices.Contains(p.Overridable, field) {
+			return fmt.Errorf("%s can't be both overridable and stricter", field)
+		}
+	}
+	return nil
+}
+
+// RepoFile is the config file in repositories.
+type RepoFile struct {
+	Trust                                 *Trust   `yaml:"trust"`
+	RequiredApprovals                     int      `yaml:"required_approvals"`
+	RequiredApprovalsWithUntrustedCommits int      `yaml:"required_approvals_with_untrusted_commits"`
+	RequiredApprovalsForSensitivePaths    int      `yaml:"required_approvals_for_sensitive_paths"`
+	RequireCodeOwnerApprovals             *bool    `yaml:"require_code_owner_approvals"`
+	BlockOnChangesRequested               *bool    `yaml:"block_on_changes_requested"`
+	SensitivePaths                        []string `yaml:"sensitive_paths"`
+}
+
+// ParseRepoFile parses the repository config file.
+// Unknown fields are rejected.
+func ParseRepoFile(data []byte) (*RepoFile, error) {
+	f := &RepoFile{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("parse %s: %w", RepoFilePath, err)
+	}
+	if f.Trust == nil {
+		f.Trust = &Trust{}
+	}
+	if err := f.Trust.Validate(); err != nil {
+		return nil, fmt.Errorf("validate trust config: %w", err)
+	}
+	if err := validateSensitivePaths(f.SensitivePaths, f.RequiredApprovalsForSensitivePaths); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// WithRepoFile returns a copy of the repository config overridden by the repository config file.
+// It returns an error if the file changes fields which RepoFile policy doesn't allow.
+// The error message is shown to users, so it describes the problem of the file.
+func (r *Repository) WithRepoFile(f *RepoFile) (*Repository, error) {
+	if r.RepoFile == nil {
+		return nil, errors.New("the repository config file isn't allowed")
+	}
+	c := *r
+	c.Trust = &Trust{
+		TrustedApps:           r.Trust.TrustedApps,
+		UntrustedMachineUsers: r.Trust.UntrustedMachineUsers,
+		ApproverTeams:         r.Trust.ApproverTeams,
+		ApproverOrgs:          r.Trust.ApproverOrgs,
+	}
+	a := &repoFileApplier{policy: r.RepoFile}
+	if f.Trust.TrustedApps != nil {
+		apps := normalizeTrustedApps(f.Trust.TrustedApps)
+		switch a.mode(repoFieldTrustedApps) {
+		case repoFieldModeOverride:
+			c.Trust.TrustedApps = apps
+		case repoFieldModeStricter:
+			// Removing apps is stricter
+			if app, ok := findUntrustedApp(apps, r.Trust.TrustedAppsMatcher); ok {
+				a.errorf("%s can only remove apps: %s isn't trusted", repoFieldTrustedApps, app)
+			}
+			c.Trust.TrustedApps = apps
+		}
+	}
+	c.Trust.UntrustedMachineUsers = a.applyPatterns(repoFieldUntrustedMachineUsers, f.Trust.UntrustedMachineUsers, r.Trust.UntrustedMachineUsers)
+	c.Trust.ApproverTeams = a.applyList(repoFieldApproverTeams, f.Trust.ApproverTeams, r.Trust.ApproverTeams)
+	c.Trust.ApproverOrgs = a.applyList(repoFieldApproverOrgs, f.Trust.ApproverOrgs, r.Trust.ApproverOrgs)
+	c.SensitivePaths = a.applyList(repoFieldSensitivePaths, f.SensitivePaths, r.SensitivePaths)
+	a.applyInt(repoFieldRequiredApprovals, f.RequiredApprovals, &c.RequiredApprovals)
+	a.applyInt(repoFieldRequiredApprovalsWithUntrustedCommits, f.RequiredApprovalsWithUntrustedCommits, &c.RequiredApprovalsWithUntrustedCommits)
+	if c.RequiredApprovals != r.RequiredApprovals && f.RequiredApprovalsWithUntrustedCommits == 0 {
+		// Like repository configs, required_approvals_with_untrusted_commits defaults to one more than required_approvals
+		c.RequiredApprovalsWithUntrustedCommits = max(c.RequiredApprovalsWithUntrustedCommits, c.RequiredApprovals+1)
+	}
+	c.RequiredApprovalsForSensitivePaths, _ = inheritRequiredApprovalsForSensitivePaths(
+		r.RequiredApprovalsForSensitivePaths, r.requiredApprovalsForSensitivePathsSet, 0, c.RequiredApprovalsWithUntrustedCommits)
+	a.applyInt(repoFieldRequiredApprovalsForSensitivePaths, f.RequiredApprovalsForSensitivePaths, &c.RequiredApprovalsForSensitivePaths)
+	if f.RequiredApprovalsForSensitivePaths != 0 {
+		c.requiredApprovalsForSensitivePathsSet = true
+	}
+	a.applyBool(repoFieldRequireCodeOwnerApprovals, f.RequireCodeOwnerApprovals, &c.RequireCodeOwnerApprovals)
+	a.applyBool(repoFieldBlockOnChangesRequested, f.BlockOnChangesRequested, &c.BlockOnChangesRequested)
+	if err := errors.Join(a.errs...); err != nil {
+		return nil, err
+	}
+	if err := c.init(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+type repoFieldMode int
+
+const (
+	repoFieldModeDenied repoFieldMode = iota
+	repoFieldModeOverride
+	repoFieldModeStricter
+)
+
+// repoFileApplier applies fields of the repository config file according to the policy and collects errors.
+type repoFileApplier struct {
+	policy *RepoFilePolicy
+	errs   []error
+}
+
+func (a *repoFileApplier) errorf(format string, args ...any) {
+	a.errs = append(a.errs, fmt.Errorf(format, args...))
+}
+
+// mode returns how the field can be changed.
+// If the field can't be changed, an error is recorded.
+func (a *repoFileApplier) mode(field string) repoFieldMode {
+	switch {
+	case slices.Contains(a.policy.Overridable, field):
+		return repoFieldModeOverride
+	case slices.Contains(a.policy.Stricter, field):
+		return repoFieldModeStricter
+	default:
+		a.errorf("%s isn't allowed to be changed", field)
+		return repoFieldModeDenied
+	}
+}
+
+// applyList returns the list overridden by the file.
+// Lists which can be made stricter are appended.
+func (a *repoFileApplier) applyList(field string, value, current []string) []string {
+	if value == nil {
+		return current
+	}
+	switch a.mode(field) {
+	case repoFieldModeOverride:
+		return slices.Clone(value)
+	case repoFieldModeStricter:
+		return mergeList(current, value, true)
+	default:
+		return current
+	}
+}
+
+// applyPatterns is applyList for lists of name patterns.
+// Negations would exclude names matched by the current list and regular expressions aren't analyzed,
+// so only names, globs, and team references can be appended to make the list stricter.
+func (a *repoFileApplier) applyPatterns(field string, value, current []string) []string {
+	if value != nil && slices.Contains(a.policy.Stricter, field) {
+		for _, pattern := range value {
+			if strings.HasPrefix(pattern, "!") || isRegexpPattern(pattern) {
+				a.errorf("%s can only add names, globs, and team references: %q", field, pattern)
+			}
+		}
+	}
+	return a.applyList(field, value, current)
+}
+
+// applyInt applies the number of required approvals. Zero means unset.
+func (a *repoFileApplier) applyInt(field string, value int, dest *int) {
+	if value == 0 {
+		return
+	}
+	switch a.mode(field) {
+	case repoFieldModeOverride:
+		*dest = value
+	case repoFieldModeStricter:
+		if value < *dest {
+			a.errorf("%s can't be decreased from %d to %d", field, *dest, value)
+			return
+		}
+		*dest = value
+	}
+}
+
+func (a *repoFileApplier) applyBool(field string, value *bool, dest **bool) {
+	if value == nil {
+		return
+	}
+	switch a.mode(field) {
+	case repoFieldModeOverride:
+		*dest = value
+	case repoFieldModeStricter:
+		if !*value && *dest != nil && **dest {
+			a.errorf("%s can't be disabled", field)
+			return
+		}
+		*dest = value
+	}
+}
+
+// findUntrustedApp returns an app pattern of the list which may match apps the current matcher doesn't trust.
+// Only app names can be kept, because patterns such as globs, negations, and regular expressions may match other apps.
+func findUntrustedApp(list []string, current *Matcher) (string, bool) {
+	for _, v := range list {
+		p, err := parsePattern(v, matcherKindApp)
+		if err != nil || p.exact == "" || p.negate || current == nil || !current.Match(v) {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// normalizeTrustedApps appends the [bot] suffix like Trust.Init without modifying the list.
+func normalizeTrustedApps(apps []string) []string {
+	normalized := make([]string, len(apps))
+	for i, app := range apps {
+		if !strings.HasSuffix(app, "[bot]") && !isRegexpPattern(app) {
+			app += "[bot]"
+		}
+		normalized[i] = app
+	}
+	return normalized
+}
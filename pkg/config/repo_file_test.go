@@ -0,0 +1,244 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+func TestRepository_WithRepoFile(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	tests := []struct {
+		name             string
+		file             string
+		wantRequired     int
+		wantUntrusted    int
+		wantSensitive    int
+		wantTrustedApps  []string
+		wantMachineUsers []string
+		wantParseErr     bool
+		wantErr          bool
+	}{
+		{
+			name:             "empty file",
+			file:             "",
+			wantRequired:     2,
+			wantTrustedApps:  []string{"dependabot[bot]", "renovate[bot]"},
+			wantMachineUsers: []string{"org-bot"},
+		},
+		{
+			name: "override and make stricter",
+			file: `
+trust:
+  trusted_apps: [renovate]
+  untrusted_machine_users: [team-bot]
+required_approvals: 3
+required_approvals_with_untrusted_commits: 3
+`,
+			wantRequired:     3,
+			wantTrustedApps:  []string{"renovate[bot]"},
+			wantMachineUsers: []string{"team-bot"},
+		},
+		{
+			name:             "required_approvals_with_untrusted_commits follows required_approvals",
+			file:             "required_approvals: 3",
+			wantRequired:     3,
+			wantUntrusted:    4,
+			wantSensitive:    4,
+			wantTrustedApps:  []string{"dependabot[bot]", "renovate[bot]"},
+			wantMachineUsers: []string{"org-bot"},
+		},
+		{
+			name:         "unknown field",
+			file:         "required_approval: 3",
+			wantParseErr: true,
+		},
+		{
+			name:    "field isn't allowed",
+			file:    "block_on_changes_requested: false",
+			wantErr: true,
+		},
+		{
+			name:    "decrease required approvals",
+			file:    "required_approvals: 1",
+			wantErr: true,
+		},
+		{
+			name:    "add a trusted app",
+			file:    "trust: {trusted_apps: [my-app]}",
+			wantErr: true,
+		},
+	}
+	cfg := &config.Config{
+		Repositories: []*config.Repository{
+			{
+				Repositories: []string{"org/*"},
+				Trust: &config.Trust{
+					UntrustedMachineUsers: []string{"org-bot"},
+				},
+				RequiredApprovals:                     2,
+				RequiredApprovalsWithUntrustedCommits: 2,
+				RepoFile: &config.RepoFilePolicy{
+					Overridable: []string{"trust.untrusted_machine_users"},
+					Stricter:    []string{"trust.trusted_apps", "required_approvals", "required_approvals_with_untrusted_commits"},
+				},
+			},
+		},
+	}
+	if err := cfg.Init(); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := cfg.GetRepo(&github.Repository{FullName: "org/app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := config.ParseRepoFile([]byte(tt.file))
+			if (err != nil) != tt.wantParseErr {
+				t.Fatalf("ParseRepoFile() error = %v, wantErr %v", err, tt.wantParseErr)
+			}
+			if tt.wantParseErr {
+				return
+			}
+			r, err := repo.WithRepoFile(f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithRepoFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if r.RequiredApprovals != tt.wantRequired {
+				t.Errorf("RequiredApprovals = %d, want %d", r.RequiredApprovals, tt.wantRequired)
+			}
+			if tt.wantUntrusted != 0 && r.RequiredApprovalsWithUntrustedCommits != tt.wantUntrusted {
+				t.Errorf("RequiredApprovalsWithUntrustedCommits = %d, want %d", r.RequiredApprovalsWithUntrustedCommits, tt.wantUntrusted)
+			}
+			if tt.wantSensitive != 0 && r.RequiredApprovalsForSensitivePaths != tt.wantSensitive {
+				t.Errorf("RequiredApprovalsForSensitivePaths = %d, want %d", r.RequiredApprovalsForSensitivePaths, tt.wantSensitive)
+			}
+			if diff := cmp.Diff(tt.wantTrustedApps, r.Trust.TrustedApps); diff != "" {
+				t.Errorf("TrustedApps mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantMachineUsers, r.Trust.UntrustedMachineUsers); diff != "" {
+				t.Errorf("UntrustedMachineUsers mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+	if repo.RequiredApprovals != 2 {
+		t.Errorf("the original config must not be modified: RequiredApprovals = %d", repo.RequiredApprovals)
+	}
+}
+
+func TestRepository_WithRepoFile_stricterLists(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	tests := []struct {
+		name             string
+		file             string
+		wantTrustedApps  []string
+		wantMachineUsers []string
+		wantErr          bool
+	}{
+		{
+			name:             "append untrusted machine users",
+			file:             "trust: {untrusted_machine_users: [team-bot, '@org/bots', 'ci-*']}",
+			wantTrustedApps:  []string{"renovate-*[bot]"},
+			wantMachineUsers: []string{"org-bot", "team-bot", "@org/bots", "ci-*"},
+		},
+		{
+			name:    "negate all untrusted machine users",
+			file:    "trust: {untrusted_machine_users: ['!*']}",
+			wantErr: true,
+		},
+		{
+			name:    "negate an untrusted machine user",
+			file:    "trust: {untrusted_machine_users: ['!org-bot']}",
+			wantErr: true,
+		},
+		{
+			name:    "regular expression of untrusted machine users",
+			file:    "trust: {untrusted_machine_users: ['/.*-bot/']}",
+			wantErr: true,
+		},
+		{
+			name:             "keep a trusted app matching the current glob",
+			file:             "trust: {trusted_apps: [renovate-foo]}",
+			wantTrustedApps:  []string{"renovate-foo[bot]"},
+			wantMachineUsers: []string{"org-bot"},
+		},
+		{
+			name:    "trusted app glob",
+			file:    "trust: {trusted_apps: ['renovate-*']}",
+			wantErr: true,
+		},
+		{
+			name:    "trusted app negation",
+			file:    "trust: {trusted_apps: ['!renovate-foo']}",
+			wantErr: true,
+		},
+		{
+			name:    "trusted app regular expression",
+			file:    "trust: {trusted_apps: ['/renovate-.*/']}",
+			wantErr: true,
+		},
+	}
+	cfg := &config.Config{
+		Repositories: []*config.Repository{
+			{
+				Repositories: []string{"org/*"},
+				Trust: &config.Trust{
+					TrustedApps:           []string{"renovate-*"},
+					UntrustedMachineUsers: []string{"org-bot"},
+				},
+				RepoFile: &config.RepoFilePolicy{
+					Stricter: []string{"trust.trusted_apps", "trust.untrusted_machine_users"},
+				},
+			},
+		},
+	}
+	if err := cfg.Init(); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := cfg.GetRepo(&github.Repository{FullName: "org/app"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			f, err := config.ParseRepoFile([]byte(tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := repo.WithRepoFile(f)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WithRepoFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tt.wantTrustedApps, r.Trust.TrustedApps); diff != "" {
+				t.Errorf("TrustedApps mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantMachineUsers, r.Trust.UntrustedMachineUsers); diff != "" {
+				t.Errorf("UntrustedMachineUsers mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRepoFilePolicy_Validate(t *testing.T) {
+	t.Parallel()
+	for _, p := range []*config.RepoFilePolicy{
+		{Overridable: []string{"insecure"}},
+		{Stricter: []string{"trust.approver_teams"}},
+		{Overridable: []string{"required_approvals"}, Stricter: []string{"required_approvals"}},
+	} {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Validate() should return an error: %+v", p)
+		}
+	}
+}
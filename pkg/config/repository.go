@@ -1,18 +1,206 @@
 package config
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
-	"path"
+	"slices"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+const (
+	ListMergeReplace = "replace"
+	ListMergeAppend  = "append"
 )
 
-func (c *Config) GetRepo(repo string) *Repository {
-	for _, r := range c.Repositories {
-		if r.Match(repo) {
-			return r
+// GetRepo returns the effective config of the repository.
+// All repository configs matching the repository are merged onto the root config
+// in ascending order of priority and then specificity, so higher priority and more specific configs take precedence.
+// Specificity is decided by the repository name pattern and then the number of selector conditions.
+// If priorities and specificities are the same, the earlier config takes precedence.
+// Only FullName of repo is required unless UsesRepoSelectors returns true.
+// It returns nil if no repository config matches the repository.
+func (c *Config) GetRepo(repo *github.Repository) (*Repository, error) {
+	type layer struct {
+		repo        *Repository
+		index       int
+		specificity int
+	}
+	var layers []*layer
+	for i, r := range c.Repositories {
+		if s := r.matcher.Specificity(repo.FullName); s >= 0 && r.Selector.Match(repo) {
+			layers = append(layers, &layer{repo: r, index: i, specificity: s})
 		}
 	}
-	return nil
+	if len(layers) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	slices.SortFunc(layers, func(a, b *layer) int {
+		return cmp.Or(
+			cmp.Compare(a.repo.Priority, b.repo.Priority),
+			cmp.Compare(a.specificity, b.specificity),
+			cmp.Compare(a.repo.Selector.conditions(), b.repo.Selector.conditions()),
+			cmp.Compare(b.index, a.index),
+		)
+	})
+	merged := c.rootRepo(repo.FullName)
+	for _, l := range layers {
+		merged.merge(l.repo)
+	}
+	if err := merged.init(); err != nil {
+		return nil, fmt.Errorf("merge repository configs: %w", err)
+	}
+	return merged, nil
+}
+
+// GetEffectiveRepo returns the effective config of the repository like GetRepo.
+// If no repository config matches the repository, it returns a repository config equivalent to the root config.
+func (c *Config) GetEffectiveRepo(repo *github.Repository) (*Repository, error) {
+	r, err := c.GetRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		return r, nil
+	}
+	return c.rootRepo(repo.FullName), nil
+}
+
+// rootRepo returns a repository config equivalent to the root config.
+func (c *Config) rootRepo(repo string) *Repository {
+	return &Repository{
+		Repositories: []string{repo},
+		Trust: &Trust{
+			TrustedApps:           c.Trust.TrustedApps,
+			UntrustedMachineUsers: c.Trust.UntrustedMachineUsers,
+			ApproverTeams:         c.Trust.ApproverTeams,
+			ApproverOrgs:          c.Trust.ApproverOrgs,
+		},
+		Insecure:                              c.Insecure,
+		RequiredApprovals:                     c.RequiredApprovals,
+		RequiredApprovalsWithUntrustedCommits: c.RequiredApprovalsWithUntrustedCommits,
+		RequireCodeOwnerApprovals:             &c.RequireCodeOwnerApprovals,
+		SensitivePaths:                        c.SensitivePaths,
+		RequiredApprovalsForSensitivePaths:    c.RequiredApprovalsForSensitivePaths,
+		requiredApprovalsForSensitivePathsSet: c.requiredApprovalsForSensitivePathsSet,
+		BlockOnChangesRequested:               &c.BlockOnChangesRequested,
+		SignaturePolicy:                       c.SignaturePolicy,
+		Keyring:                               c.Keyring,
+		Gitsign:                               c.Gitsign,
+		RepoFile:                              c.RepoFile,
+	}
+}
+
+// merge overrides the config with the layer.
+// Unset fields of the layer are inherited and lists are replaced or appended according to list_merge of the layer.
+func (r *Repository) merge(layer *Repository) {
+	appendLists := layer.ListMerge == ListMergeAppend
+	r.Trust = mergeTrust(r.Trust, layer.Trust, appendLists)
+	r.Insecure = mergeInsecure(r.Insecure, layer.Insecure, appendLists)
+	if layer.Ignored != nil {
+		r.Ignored = layer.Ignored
+	}
+	r.RequiredApprovals, r.RequiredApprovalsWithUntrustedCommits = inheritRequiredApprovals(
+		r.RequiredApprovals, r.RequiredApprovalsWithUntrustedCommits,
+		layer.RequiredApprovals, layer.RequiredApprovalsWithUntrustedCommits)
+	if layer.RequireCodeOwnerApprovals != nil {
+		r.RequireCodeOwnerApprovals = layer.RequireCodeOwnerApprovals
+	}
+	r.SensitivePaths = mergeList(r.SensitivePaths, layer.SensitivePaths, appendLists)
+	r.RequiredApprovalsForSensitivePaths, r.requiredApprovalsForSensitivePathsSet = inheritRequiredApprovalsForSensitivePaths(
+		r.RequiredApprovalsForSensitivePaths, r.requiredApprovalsForSensitivePathsSet,
+		layer.RequiredApprovalsForSensitivePaths, r.RequiredApprovalsWithUntrustedCommits)
+	if layer.BlockOnChangesRequested != nil {
+		r.BlockOnChangesRequested = layer.BlockOnChangesRequested
+	}
+	if layer.SignaturePolicy != nil {
+		r.SignaturePolicy = layer.SignaturePolicy
+	}
+	if layer.Keyring != nil {
+		r.Keyring = layer.Keyring
+	}
+	if layer.Gitsign != nil {
+		r.Gitsign = layer.Gitsign
+	}
+	if layer.RepoFile != nil {
+		r.RepoFile = layer.RepoFile
+	}
+	switch {
+	case layer.Branches == nil:
+	case appendLists:
+		// Branch rules are evaluated in order, so rules of the layer take precedence over inherited rules.
+		r.Branches = slices.Concat(layer.Branches, r.Branches)
+	default:
+		r.Branches = slices.Clone(layer.Branches)
+	}
+}
+
+// mergeTrust merges trust configs with mergeList.
+func mergeTrust(base, layer *Trust, appendLists bool) *Trust {
+	return &Trust{
+		TrustedApps:           mergeList(base.TrustedApps, layer.TrustedApps, appendLists),
+		UntrustedMachineUsers: mergeList(base.UntrustedMachineUsers, layer.UntrustedMachineUsers, appendLists),
+		ApproverTeams:         mergeList(base.ApproverTeams, layer.ApproverTeams, appendLists),
+		ApproverOrgs:          mergeList(base.ApproverOrgs, layer.ApproverOrgs, appendLists),
+	}
+}
+
+// mergeInsecure merges insecure configs like mergeList.
+// allow_unsigned_commits and the lists of unsigned commits exclude each other.
+func mergeInsecure(base, layer *Insecure, appendLists bool) *Insecure {
+	if layer == nil {
+		return base
+	}
+	if base == nil {
+		base = &Insecure{}
+	}
+	insecure := &Insecure{
+		AllowUnsignedCommits:       base.AllowUnsignedCommits,
+		UnsignedCommitApps:         base.UnsignedCommitApps,
+		UnsignedCommitMachineUsers: base.UnsignedCommitMachineUsers,
+	}
+	if layer.UnsignedCommitApps != nil {
+		insecure.UnsignedCommitApps = mergeList(base.UnsignedCommitApps, layer.UnsignedCommitApps, appendLists)
+		insecure.AllowUnsignedCommits = new(false)
+	}
+	if layer.UnsignedCommitMachineUsers != nil {
+		insecure.UnsignedCommitMachineUsers = mergeList(base.UnsignedCommitMachineUsers, layer.UnsignedCommitMachineUsers, appendLists)
+		insecure.AllowUnsignedCommits = new(false)
+	}
+	if layer.AllowUnsignedCommits != nil {
+		insecure.AllowUnsignedCommits = layer.AllowUnsignedCommits
+		if *layer.AllowUnsignedCommits {
+			insecure.UnsignedCommitApps = nil
+			insecure.UnsignedCommitMachineUsers = nil
+		}
+	}
+	return insecure
+}
+
+// mergeList returns a new list so that initializing the merged config doesn't modify the original configs.
+// A nil list of the layer inherits the base list, while an empty list replaces it.
+func mergeList(base, layer []string, appendLists bool) []string {
+	if layer == nil {
+		return base
+	}
+	if !appendLists {
+		return slices.Clone(layer)
+	}
+	list := make([]string, 0, len(base)+len(layer))
+	list = append(list, base...)
+	return append(list, layer...)
+}
+
+// init validates and initializes the merged config.
+func (r *Repository) init() error {
+	if err := validateRequiredApprovals(r.RequiredApprovals, r.RequiredApprovalsWithUntrustedCommits); err != nil {
+		return err
+	}
+	if err := r.Trust.Init(); err != nil {
+		return fmt.Errorf("initialize trust config: %w", err)
+	}
+	return r.validateBranches()
 }
 
 func (c *Config) initRepos() error {
@@ -20,25 +208,70 @@ func (c *Config) initRepos() error {
 		if err := repo.Validate(); err != nil {
 			return fmt.Errorf("validate a repository config: %w", err)
 		}
-		if repo.Insecure == nil {
-			repo.Insecure = c.Insecure
+		if err := validateRequiredApprovals(repo.RequiredApprovals, repo.RequiredApprovalsWithUntrustedCommits); err != nil {
+			return fmt.Errorf("validate a repository config: %w", err)
+		}
+		if repo.Keyring != nil {
+			if err := repo.Keyring.Init(); err != nil {
+				return fmt.Errorf("initialize keyring of a repository config: %w", err)
+			}
+		}
+		if repo.Gitsign != nil {
+			if err := repo.Gitsign.Init(); err != nil {
+				return fmt.Errorf("initialize gitsign of a repository config: %w", err)
+			}
 		}
-		if repo.Trust.TrustedApps == nil {
-			repo.Trust.TrustedApps = c.Trust.TrustedApps
+		if err := repo.initBranches(); err != nil {
+			return fmt.Errorf("initialize branch rules of a repository config: %w", err)
 		}
-		if repo.Trust.UntrustedMachineUsers == nil {
-			repo.Trust.UntrustedMachineUsers = c.Trust.UntrustedMachineUsers
+		// Settings are inherited from the root config, so the repository config is validated with it
+		merged := c.rootRepo("")
+		merged.merge(repo)
+		if err := merged.init(); err != nil {
+			return fmt.Errorf("validate a repository config merged onto the root config: %w", err)
+		}
+	}
+	return c.validateEffectiveRepos()
+}
+
+// validateEffectiveRepos validates effective configs of repositories which repository name patterns represent, like Diff.
+// Repository configs matching the same repositories are merged, so their combination may be invalid even if each of them is valid.
+func (c *Config) validateEffectiveRepos() error {
+	for _, name := range diffPatterns(c) {
+		if _, err := c.GetRepo(&github.Repository{FullName: name}); err != nil {
+			return fmt.Errorf("validate the effective config of %s: %w", name, err)
 		}
-		repo.Trust.Init()
 	}
 	return nil
 }
 
 type Repository struct {
-	Repositories []string  `json:"repositories" yaml:"repositories"`
-	Trust        *Trust    `json:"trust" yaml:"trust"`
-	Insecure     *Insecure `json:"insecure,omitempty" yaml:"insecure"`
-	Ignored      bool      `json:"ignored,omitempty" yaml:"ignored"`
+	Repositories []string `json:"repositories" yaml:"repositories"`
+	// Selector selects repositories matching Repositories by metadata
+	Selector *RepoSelector `json:"selector,omitempty" yaml:"selector"`
+	// Priority decides the order to merge repository configs matching a repository.
+	// Configs with higher priorities take precedence.
+	Priority int `json:"priority,omitempty" yaml:"priority"`
+	// ListMerge is either "replace" or "append".
+	// It decides whether lists of the config replace or are appended to lists of less specific configs.
+	ListMerge                             string           `json:"list_merge,omitempty" yaml:"list_merge"`
+	Trust                                 *Trust           `json:"trust" yaml:"trust"`
+	Insecure                              *Insecure        `json:"insecure,omitempty" yaml:"insecure"`
+	Ignored                               *bool            `json:"ignored,omitempty" yaml:"ignored"`
+	RequiredApprovals                     int              `json:"required_approvals,omitempty" yaml:"required_approvals"`
+	RequiredApprovalsWithUntrustedCommits int              `json:"required_approvals_with_untrusted_commits,omitempty" yaml:"required_approvals_with_untrusted_commits"`
+	RequireCodeOwnerApprovals             *bool            `json:"require_code_owner_approvals,omitempty" yaml:"require_code_owner_approvals"`
+	SensitivePaths                        []string         `json:"sensitive_paths,omitempty" yaml:"sensitive_paths"`
+	RequiredApprovalsForSensitivePaths    int              `json:"required_approvals_for_sensitive_paths,omitempty" yaml:"required_approvals_for_sensitive_paths"`
+	BlockOnChangesRequested               *bool            `json:"block_on_changes_requested,omitempty" yaml:"block_on_changes_requested"`
+	SignaturePolicy                       *SignaturePolicy `json:"signature_policy,omitempty" yaml:"signature_policy"`
+	Keyring                               *Keyring         `json:"keyring,omitempty" yaml:"keyring"`
+	Gitsign                               *Gitsign         `json:"gitsign,omitempty" yaml:"gitsign"`
+	Branches                              []*BranchRule    `json:"branches,omitempty" yaml:"branches"`
+	RepoFile                              *RepoFilePolicy  `json:"repo_file,omitempty" yaml:"repo_file"`
+	matcher                               *Matcher
+	// requiredApprovalsForSensitivePathsSet is true if required_approvals_for_sensitive_paths is set explicitly in the merged layers
+	requiredApprovalsForSensitivePathsSet bool
 }
 
 func (r *Repository) Validate() error {
@@ -48,31 +281,51 @@ func (r *Repository) Validate() error {
 	if r.Trust == nil {
 		return errors.New("trust is required")
 	}
-	for _, pattern := range r.Repositories {
-		if _, err := path.Match(pattern, "suzuki-shunsuke/validate-pr-review-app"); err != nil {
-			return fmt.Errorf("invalid repository pattern %q: %w", pattern, err)
+	switch r.ListMerge {
+	case "", ListMergeReplace, ListMergeAppend:
+	default:
+		return fmt.Errorf("list_merge must be either replace or append: %q", r.ListMerge)
+	}
+	matcher, err := NewRepoMatcher(r.Repositories)
+	if err != nil {
+		return fmt.Errorf("repositories: %w", err)
+	}
+	r.matcher = matcher
+	if r.Selector != nil {
+		if err := r.Selector.Validate(); err != nil {
+			return fmt.Errorf("validate selector: %w", err)
 		}
 	}
 	if err := r.Trust.Validate(); err != nil {
 		return fmt.Errorf("validate trust config: %w", err)
 	}
+	if err := validateSensitivePaths(r.SensitivePaths, r.RequiredApprovalsForSensitivePaths); err != nil {
+		return err
+	}
 	if r.Insecure != nil {
 		if err := r.Insecure.Validate(); err != nil {
 			return fmt.Errorf("validate insecure config: %w", err)
 		}
 	}
-	return nil
-}
-
-func (r *Repository) Match(repo string) bool {
-	for _, pattern := range r.Repositories {
-		matched, err := path.Match(pattern, repo)
-		if err != nil {
-			continue
+	if r.SignaturePolicy != nil {
+		if err := r.SignaturePolicy.Validate(); err != nil {
+			return fmt.Errorf("validate signature_policy: %w", err)
 		}
-		if matched {
-			return true
+	}
+	if r.RepoFile != nil {
+		if err := r.RepoFile.Validate(); err != nil {
+			return fmt.Errorf("validate repo_file: %w", err)
 		}
 	}
-	return false
+	return nil
+}
+
+// Match reports whether the repository matches the repository name patterns and the selector.
+func (r *Repository) Match(repo *github.Repository) bool {
+	return r.matcher.Match(repo.FullName) && r.Selector.Match(repo)
+}
+
+// IsIgnored reports whether the repository is ignored.
+func (r *Repository) IsIgnored() bool {
+	return r.Ignored != nil && *r.Ignored
 }
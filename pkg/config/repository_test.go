@@ -0,0 +1,185 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+func TestConfig_GetRepo(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	tests := []struct {
+		name              string
+		repos             []*config.Repository
+		repo              string
+		wantNil           bool
+		wantRequired      int
+		wantWithUntrusted int
+		wantSensitive     int
+		wantTrustedApps   []string
+		wantMachineUsers  []string
+		wantIgnored       bool
+		wantCodeOwners    bool
+		wantSensitivePath []string
+		wantErr           bool
+	}{
+		{
+			name: "no repository config matches",
+			repos: []*config.Repository{
+				{Repositories: []string{"org/*"}, Trust: &config.Trust{}},
+			},
+			repo:    "other/repo",
+			wantNil: true,
+		},
+		{
+			name: "more specific configs take precedence regardless of the order",
+			repos: []*config.Repository{
+				{Repositories: []string{"org/infra-prod"}, Trust: &config.Trust{}, RequiredApprovals: 3, RequiredApprovalsWithUntrustedCommits: 3},
+				{Repositories: []string{"org/infra-*"}, Trust: &config.Trust{UntrustedMachineUsers: []string{"infra-bot"}}, RequiredApprovals: 2},
+				{Repositories: []string{"org/*"}, Trust: &config.Trust{TrustedApps: []string{"renovate"}}, RequireCodeOwnerApprovals: new(true), RequiredApprovalsWithUntrustedCommits: 4},
+			},
+			repo:             "org/infra-prod",
+			wantRequired:     3,
+			wantTrustedApps:  []string{"renovate[bot]"},
+			wantMachineUsers: []string{"infra-bot"},
+			wantCodeOwners:   true,
+		},
+		{
+			name: "priority takes precedence over specificity",
+			repos: []*config.Repository{
+				{Repositories: []string{"org/*"}, Trust: &config.Trust{}, Priority: 1, RequiredApprovals: 2},
+				{Repositories: []string{"org/infra"}, Trust: &config.Trust{}, RequiredApprovals: 1},
+			},
+			repo:             "org/infra",
+			wantRequired:     2,
+			wantTrustedApps:  []string{"dependabot[bot]", "renovate[bot]"},
+			wantMachineUsers: []string{"root-bot"},
+		},
+		{
+			name: "the earlier config takes precedence if priorities and specificities are the same",
+			repos: []*config.Repository{
+				{Repositories: []string{"org/infra"}, Trust: &config.Trust{}, Ignored: new(true)},
+				{Repositories: []string{"org/infra"}, Trust: &config.Trust{}, Ignored: new(false)},
+			},
+			repo:             "org/infra",
+			wantRequired:     1,
+			wantTrustedApps:  []string{"dependabot[bot]", "renovate[bot]"},
+			wantMachineUsers: []string{"root-bot"},
+			wantIgnored:      true,
+		},
+		{
+			name: "append lists",
+			repos: []*config.Repository{
+				{Repositories: []string{"org/*"}, Trust: &config.Trust{UntrustedMachineUsers: []string{"org-bot"}}, SensitivePaths: []string{"CODEOWNERS"}},
+				{Repositories: []string{"org/infra"}, ListMerge: config.ListMergeAppend, Trust: &config.Trust{UntrustedMachineUsers: []string{"infra-bot"}}, SensitivePaths: []string{"terraform/**"}},
+			},
+			repo:              "org/infra",
+			wantRequired:      1,
+			wantTrustedApps:   []string{"dependabot[bot]", "renovate[bot]"},
+			wantMachineUsers:  []string{"org-bot", "infra-bot"},
+			wantSensitivePath: []string{"CODEOWNERS", "terraform/**"},
+		},
+		{
+			name: "required_approvals_with_untrusted_commits defaults to one more than required_approvals of the layer",
+			repos: []*config.Repository{
+				{Repositories: []string{"org/*"}, Trust: &config.Trust{}, RequiredApprovalsWithUntrustedCommits: 2},
+				{Repositories: []string{"org/infra"}, Trust: &config.Trust{}, RequiredApprovals: 3},
+			},
+			repo:              "org/infra",
+			wantRequired:      3,
+			wantWithUntrusted: 4,
+			wantTrustedApps:   []string{"dependabot[bot]", "renovate[bot]"},
+			wantMachineUsers:  []string{"root-bot"},
+		},
+		{
+			name: "the inherited required_approvals_with_untrusted_commits is kept if it's greater",
+			repos: []*config.Repository{
+				{Repositories: []string{"org/*"}, Trust: &config.Trust{}, RequiredApprovalsWithUntrustedCommits: 5},
+				{Repositories: []string{"org/infra"}, Trust: &config.Trust{}, RequiredApprovals: 2},
+			},
+			repo:              "org/infra",
+			wantRequired:      2,
+			wantWithUntrusted: 5,
+			wantTrustedApps:   []string{"dependabot[bot]", "renovate[bot]"},
+			wantMachineUsers:  []string{"root-bot"},
+		},
+		{
+			name: "required_approvals_for_sensitive_paths defaults to required_approvals_with_untrusted_commits of the layer",
+			repos: []*config.Repository{
+				{Repositories: []string{"org/infra"}, Trust: &config.Trust{}, RequiredApprovalsWithUntrustedCommits: 3},
+			},
+			repo:              "org/infra",
+			wantRequired:      1,
+			wantWithUntrusted: 3,
+			wantSensitive:     3,
+			wantTrustedApps:   []string{"dependabot[bot]", "renovate[bot]"},
+			wantMachineUsers:  []string{"root-bot"},
+		},
+		{
+			name: "the explicit required_approvals_for_sensitive_paths is inherited",
+			repos: []*config.Repository{
+				{Repositories: []string{"org/*"}, Trust: &config.Trust{}, RequiredApprovalsForSensitivePaths: 2},
+				{Repositories: []string{"org/infra"}, Trust: &config.Trust{}, RequiredApprovalsWithUntrustedCommits: 4},
+			},
+			repo:              "org/infra",
+			wantRequired:      1,
+			wantWithUntrusted: 4,
+			wantSensitive:     2,
+			wantTrustedApps:   []string{"dependabot[bot]", "renovate[bot]"},
+			wantMachineUsers:  []string{"root-bot"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &config.Config{
+				Trust: &config.Trust{
+					UntrustedMachineUsers: []string{"root-bot"},
+				},
+				Repositories: tt.repos,
+			}
+			if err := cfg.Init(); err != nil {
+				t.Fatal(err)
+			}
+			repo, err := cfg.GetRepo(&github.Repository{FullName: tt.repo})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRepo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.wantNil {
+				if repo != nil {
+					t.Errorf("GetRepo() = %v, want nil", repo)
+				}
+				return
+			}
+			if repo.RequiredApprovals != tt.wantRequired {
+				t.Errorf("RequiredApprovals = %d, want %d", repo.RequiredApprovals, tt.wantRequired)
+			}
+			if tt.wantWithUntrusted != 0 && repo.RequiredApprovalsWithUntrustedCommits != tt.wantWithUntrusted {
+				t.Errorf("RequiredApprovalsWithUntrustedCommits = %d, want %d", repo.RequiredApprovalsWithUntrustedCommits, tt.wantWithUntrusted)
+			}
+			if tt.wantSensitive != 0 && repo.RequiredApprovalsForSensitivePaths != tt.wantSensitive {
+				t.Errorf("RequiredApprovalsForSensitivePaths = %d, want %d", repo.RequiredApprovalsForSensitivePaths, tt.wantSensitive)
+			}
+			if diff := cmp.Diff(tt.wantTrustedApps, repo.Trust.TrustedApps); diff != "" {
+				t.Errorf("TrustedApps mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantMachineUsers, repo.Trust.UntrustedMachineUsers); diff != "" {
+				t.Errorf("UntrustedMachineUsers mismatch (-want +got):\n%s", diff)
+			}
+			if repo.IsIgnored() != tt.wantIgnored {
+				t.Errorf("IsIgnored() = %v, want %v", repo.IsIgnored(), tt.wantIgnored)
+			}
+			if *repo.RequireCodeOwnerApprovals != tt.wantCodeOwners {
+				t.Errorf("RequireCodeOwnerApprovals = %v, want %v", *repo.RequireCodeOwnerApprovals, tt.wantCodeOwners)
+			}
+			if diff := cmp.Diff(tt.wantSensitivePath, repo.SensitivePaths); diff != "" {
+				t.Errorf("SensitivePaths mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
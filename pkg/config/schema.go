@@ -0,0 +1,190 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/invopop/jsonschema"
+	schemavalidator "github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"gopkg.in/yaml.v3"
+)
+
+const schemaURL = "config.json"
+
+// compileSchema compiles the JSON Schema of the config.
+// The schema is generated from Config in the same way as cmd/gen-jsonschema, so it's equal to json-schema/config.json.
+var compileSchema = sync.OnceValues(func() (*schemavalidator.Schema, error) { //nolint:gochecknoglobals
+	b, err := json.Marshal(jsonschema.Reflect(&Config{}))
+	if err != nil {
+		return nil, fmt.Errorf("marshal the JSON Schema: %w", err)
+	}
+	doc, err := schemavalidator.UnmarshalJSON(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal the JSON Schema: %w", err)
+	}
+	c := schemavalidator.NewCompiler()
+	if err := c.AddResource(schemaURL, doc); err != nil {
+		return nil, fmt.Errorf("add the JSON Schema: %w", err)
+	}
+	sch, err := c.Compile(schemaURL)
+	if err != nil {
+		return nil, fmt.Errorf("compile the JSON Schema: %w", err)
+	}
+	return sch, nil
+})
+
+// validateSchema validates the resolved config against the JSON Schema.
+// Unknown fields are rejected.
+// Each error reports the file, line, column, and path of the field.
+func (l *loader) validateSchema(root *yaml.Node) error {
+	sch, err := compileSchema()
+	if err != nil {
+		return err
+	}
+	var v any
+	if err := root.Decode(&v); err != nil {
+		return fmt.Errorf("parse the config: %w", err)
+	}
+	// Fields with null values are treated as unset like yaml.Unmarshal does
+	b, err := json.Marshal(removeNull(v))
+	if err != nil {
+		return fmt.Errorf("convert the config to JSON: %w", err)
+	}
+	inst, err := schemavalidator.UnmarshalJSON(bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("convert the config to JSON: %w", err)
+	}
+	err = sch.Validate(inst)
+	if err == nil {
+		return nil
+	}
+	var verr *schemavalidator.ValidationError
+	if !errors.As(err, &verr) {
+		return fmt.Errorf("validate the config: %w", err)
+	}
+	var errs []error
+	p := message.NewPrinter(language.English)
+	for _, leaf := range leafErrors(verr) {
+		errs = append(errs, l.schemaErrors(root, leaf, p)...)
+	}
+	return fmt.Errorf("the config is invalid:\n%w", errors.Join(errs...))
+}
+
+// schemaErrors converts the validation error to errors with locations in config files.
+func (l *loader) schemaErrors(root *yaml.Node, verr *schemavalidator.ValidationError, p *message.Printer) []error {
+	node, path := locate(root, verr.InstanceLocation)
+	if k, ok := verr.ErrorKind.(*kind.AdditionalProperties); ok {
+		errs := make([]error, len(k.Properties))
+		for i, prop := range slices.Sorted(slices.Values(k.Properties)) {
+			key := node
+			if node != nil {
+				key = mappingKey(node, prop)
+			}
+			errs[i] = fmt.Errorf("%s: %s: unknown field", l.position(key), joinYAMLPath(path, prop))
+		}
+		return errs
+	}
+	if path == "" {
+		path = "."
+	}
+	return []error{fmt.Errorf("%s: %s: %s", l.position(node), path, verr.ErrorKind.LocalizedString(p))}
+}
+
+// position returns the file, line, and column of the node.
+func (l *loader) position(node *yaml.Node) string {
+	if node == nil {
+		return "unknown position"
+	}
+	return fmt.Sprintf("%s:%d:%d", l.files[node], node.Line, node.Column)
+}
+
+// leafErrors returns the most specific validation errors.
+func leafErrors(verr *schemavalidator.ValidationError) []*schemavalidator.ValidationError {
+	if len(verr.Causes) == 0 {
+		return []*schemavalidator.ValidationError{verr}
+	}
+	var leaves []*schemavalidator.ValidationError
+	for _, cause := range verr.Causes {
+		leaves = append(leaves, leafErrors(cause)...)
+	}
+	return leaves
+}
+
+// locate returns the node at the JSON Schema instance location and its path like repositories[0].trust.
+func locate(root *yaml.Node, location []string) (*yaml.Node, string) {
+	node := root
+	var path strings.Builder
+	for _, token := range location {
+		if node == nil {
+			// The path is still reported even if the node isn't found
+			path.WriteString("." + token)
+			continue
+		}
+		switch node.Kind {
+		case yaml.MappingNode:
+			if path.Len() > 0 {
+				path.WriteString(".")
+			}
+			path.WriteString(token)
+			node = mappingValue(node, token)
+		case yaml.SequenceNode:
+			path.WriteString("[" + token + "]")
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(node.Content) {
+				node = nil
+				continue
+			}
+			node = node.Content[i]
+		default:
+			node = nil
+		}
+	}
+	return node, path.String()
+}
+
+func joinYAMLPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func mappingKey(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i]
+		}
+	}
+	return nil
+}
+
+// removeNull removes fields with null values recursively.
+func removeNull(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			if e == nil {
+				delete(v, k)
+				continue
+			}
+			v[k] = removeNull(e)
+		}
+		return v
+	case []any:
+		for i, e := range v {
+			v[i] = removeNull(e)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+// RepoSelector selects repositories by metadata in addition to repository names.
+// All set conditions must be satisfied.
+type RepoSelector struct {
+	// Topics selects repositories having any of the topics
+	Topics []string `json:"topics,omitempty" yaml:"topics"`
+	// Visibility selects repositories whose visibility is any of public, private, and internal
+	Visibility []string `json:"visibility,omitempty" yaml:"visibility"`
+	Archived   *bool    `json:"archived,omitempty" yaml:"archived"`
+	Fork       *bool    `json:"fork,omitempty" yaml:"fork"`
+	// CustomProperties selects repositories whose custom properties have any of the values per property
+	CustomProperties map[string][]string `json:"custom_properties,omitempty" yaml:"custom_properties"`
+}
+
+func (s *RepoSelector) Validate() error {
+	for _, v := range s.Visibility {
+		switch v {
+		case "public", "private", "internal":
+		default:
+			return fmt.Errorf("visibility must be public, private, or internal: %q", v)
+		}
+	}
+	for name, values := range s.CustomProperties {
+		if len(values) == 0 {
+			return fmt.Errorf("values of the custom property %q are empty", name)
+		}
+	}
+	if s.conditions() == 0 {
+		return errors.New("selector has no condition")
+	}
+	return nil
+}
+
+// conditions returns the number of set conditions.
+// Selectors with more conditions are more specific.
+func (s *RepoSelector) conditions() int {
+	if s == nil {
+		return 0
+	}
+	n := len(s.CustomProperties)
+	if len(s.Topics) > 0 {
+		n++
+	}
+	if len(s.Visibility) > 0 {
+		n++
+	}
+	if s.Archived != nil {
+		n++
+	}
+	if s.Fork != nil {
+		n++
+	}
+	return n
+}
+
+// Match reports whether the repository satisfies the selector.
+// A nil selector matches any repository.
+func (s *RepoSelector) Match(repo *github.Repository) bool {
+	if s == nil {
+		return true
+	}
+	if len(s.Topics) > 0 && !slices.ContainsFunc(s.Topics, func(topic string) bool {
+		return slices.Contains(repo.Topics, strings.ToLower(topic))
+	}) {
+		return false
+	}
+	if len(s.Visibility) > 0 && !slices.Contains(s.Visibility, repo.Visibility) {
+		return false
+	}
+	if s.Archived != nil && *s.Archived != repo.Archived {
+		return false
+	}
+	if s.Fork != nil && *s.Fork != repo.Fork {
+		return false
+	}
+	for name, values := range s.CustomProperties {
+		if !slices.ContainsFunc(repo.CustomProperties[name], func(v string) bool {
+			return slices.Contains(values, v)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+// UsesRepoSelectors reports whether any repository config has a selector.
+// If so, metadata of repositories is required to get repository configs.
+func (c *Config) UsesRepoSelectors() bool {
+	for _, r := range c.Repositories {
+		if r.Selector != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,152 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+func TestRepoSelector_Match(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	repo := &github.Repository{
+		FullName:   "org/infra",
+		Topics:     []string{"terraform", "aws"},
+		Visibility: "private",
+		CustomProperties: map[string][]string{
+			"compliance": {"sox", "pci"},
+		},
+	}
+	tests := []struct {
+		name     string
+		selector *config.RepoSelector
+		want     bool
+	}{
+		{
+			name: "nil selector",
+			want: true,
+		},
+		{
+			name:     "any of topics",
+			selector: &config.RepoSelector{Topics: []string{"Terraform", "go"}},
+			want:     true,
+		},
+		{
+			name:     "no topic",
+			selector: &config.RepoSelector{Topics: []string{"go"}},
+		},
+		{
+			name:     "visibility",
+			selector: &config.RepoSelector{Visibility: []string{"public"}},
+		},
+		{
+			name:     "archived and fork",
+			selector: &config.RepoSelector{Archived: new(false), Fork: new(false)},
+			want:     true,
+		},
+		{
+			name:     "archived",
+			selector: &config.RepoSelector{Archived: new(true)},
+		},
+		{
+			name: "custom properties",
+			selector: &config.RepoSelector{CustomProperties: map[string][]string{
+				"compliance": {"sox"},
+			}},
+			want: true,
+		},
+		{
+			name: "custom property isn't set",
+			selector: &config.RepoSelector{CustomProperties: map[string][]string{
+				"team": {"platform"},
+			}},
+		},
+		{
+			name: "all conditions must be satisfied",
+			selector: &config.RepoSelector{
+				Topics:     []string{"terraform"},
+				Visibility: []string{"public"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.selector.Match(repo); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_GetRepo_selector(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		Repositories: []*config.Repository{
+			{
+				Repositories: []string{"org/*"},
+				Trust:        &config.Trust{},
+				Selector: &config.RepoSelector{
+					CustomProperties: map[string][]string{"compliance": {"sox"}},
+				},
+				RequiredApprovals:                     2,
+				RequiredApprovalsWithUntrustedCommits: 2,
+			},
+			{
+				Repositories:      []string{"org/*"},
+				Trust:             &config.Trust{},
+				RequiredApprovals: 1,
+			},
+		},
+	}
+	if err := cfg.Init(); err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.UsesRepoSelectors() {
+		t.Error("UsesRepoSelectors() = false, want true")
+	}
+	tests := []struct {
+		name string
+		repo *github.Repository
+		want int
+	}{
+		{
+			name: "configs with selectors are more specific",
+			repo: &github.Repository{
+				FullName:         "org/billing",
+				CustomProperties: map[string][]string{"compliance": {"sox"}},
+			},
+			want: 2,
+		},
+		{
+			name: "selector doesn't match",
+			repo: &github.Repository{FullName: "org/docs"},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			repo, err := cfg.GetRepo(tt.repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if repo.RequiredApprovals != tt.want {
+				t.Errorf("RequiredApprovals = %d, want %d", repo.RequiredApprovals, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepoSelector_Validate(t *testing.T) {
+	t.Parallel()
+	for _, s := range []*config.RepoSelector{
+		{},
+		{Visibility: []string{"secret"}},
+		{CustomProperties: map[string][]string{"compliance": {}}},
+	} {
+		if err := s.Validate(); err == nil {
+			t.Errorf("Validate() should return an error: %+v", s)
+		}
+	}
+}
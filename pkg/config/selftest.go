@@ -0,0 +1,253 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	v4 "github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github/v4"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+// signatureUnsigned is the signature state of test commits which aren't signed.
+const signatureUnsigned = "UNSIGNED"
+
+// testStates are states which test cases can expect.
+var testStates = []validation.State{ //nolint:gochecknoglobals
+	validation.StateApproved,
+	validation.StateApprovalIsRequired,
+	validation.StateTwoApprovalsAreRequired,
+	validation.StateChangesRequested,
+}
+
+// Test is a test case of the config.
+// The pull request is validated with the effective policy of the repository, and the result must be the expected one.
+// Tests run when the config is initialized, so a config changing decisions unexpectedly can't be deployed.
+type Test struct {
+	Name string `json:"name,omitempty" yaml:"name"`
+	// Repo is the full name of the repository like owner/repo
+	Repo    string `json:"repo" yaml:"repo"`
+	BaseRef string `json:"base_ref,omitempty" yaml:"base_ref"`
+	// Members are members of teams (<org>/<team>) and organizations.
+	// They're used to resolve team references and approver teams and organizations instead of GitHub API.
+	Members     map[string][]string `json:"members,omitempty" yaml:"members"`
+	PullRequest *TestPullRequest    `json:"pull_request" yaml:"pull_request"`
+	Want        *TestWant           `json:"want" yaml:"want"`
+}
+
+// TestPullRequest is a pull request of the test case.
+type TestPullRequest struct {
+	Approvers         []*TestUser   `json:"approvers,omitempty" yaml:"approvers"`
+	ChangesRequesters []*TestUser   `json:"changes_requesters,omitempty" yaml:"changes_requesters"`
+	Commits           []*TestCommit `json:"commits,omitempty" yaml:"commits"`
+}
+
+type TestUser struct {
+	Login string `json:"login" yaml:"login"`
+	IsApp bool   `json:"is_app,omitempty" yaml:"is_app"`
+}
+
+// TestCommit is a commit of the test case.
+// If Committer is empty, the commit isn't linked to any GitHub user.
+type TestCommit struct {
+	Committer string `json:"committer,omitempty" yaml:"committer"`
+	Author    string `json:"author,omitempty" yaml:"author"`
+	IsApp     bool   `json:"is_app,omitempty" yaml:"is_app"`
+	// Signature is the signature state such as VALID and INVALID. UNSIGNED means the commit isn't signed. The default is VALID.
+	Signature string `json:"signature,omitempty" yaml:"signature"`
+}
+
+// TestWant is the expected result of the test case.
+type TestWant struct {
+	State validation.State `json:"state" yaml:"state"`
+	// Reasons are reasons why more approvals are required, such as "self-approval" and "untrusted app commits".
+	// If Reasons is nil, reasons aren't checked.
+	Reasons []string `json:"reasons,omitempty" yaml:"reasons"`
+}
+
+func (t *Test) Validate() error {
+	if t.Repo == "" {
+		return errors.New("repo is required")
+	}
+	if t.PullRequest == nil {
+		return errors.New("pull_request is required")
+	}
+	if t.Want == nil || t.Want.State == "" {
+		return errors.New("want.state is required")
+	}
+	if !slices.Contains(testStates, t.Want.State) {
+		return fmt.Errorf("want.state must be one of %q", testStates)
+	}
+	return nil
+}
+
+func (t *Test) title(i int) string {
+	if t.Name != "" {
+		return strconv.Quote(t.Name)
+	}
+	return "tests[" + strconv.Itoa(i) + "]"
+}
+
+// runTests runs test cases and returns their results.
+// Failures of all test cases are reported together.
+func (c *Config) runTests() ([]*validation.Result, error) {
+	validator := validation.New(&validation.InputNew{})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	results := make([]*validation.Result, 0, len(c.Tests))
+	var errs []error
+	for i, test := range c.Tests {
+		result, err := c.runTest(validator, logger, test)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("test %s: %w", test.title(i), err))
+			continue
+		}
+		results = append(results, result)
+		if err := test.Want.compare(result); err != nil {
+			errs = append(errs, fmt.Errorf("test %s failed: %w", test.title(i), err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("run tests of the config:\n%w", err)
+	}
+	return results, nil
+}
+
+func (c *Config) runTest(validator *validation.Validator, logger *slog.Logger, test *Test) (*validation.Result, error) {
+	if err := test.Validate(); err != nil {
+		return nil, err
+	}
+	repo, err := c.GetEffectiveRepo(&github.Repository{FullName: test.Repo})
+	if err != nil {
+		return nil, fmt.Errorf("get the repository config: %w", err)
+	}
+	policy, err := NewPolicy(c, repo, test.BaseRef)
+	if err != nil {
+		return nil, err
+	}
+	pr := test.PullRequest.pullRequest(test.BaseRef)
+	input := policy.NewInput(pr)
+	// Commits of test cases have no signature payload
+	input.Keyring = nil
+	input.Gitsign = nil
+	teams := test.teamMembers()
+	input.Trust.UntrustedMachineUsers = policy.Trust.UntrustedMachineUsersMatcher.WithTeamMembers(teams)
+	if input.Insecure != nil {
+		input.Insecure.UnsignedCommitMachineUsers = policy.Insecure.UnsignedCommitMachineUsersMatcher.WithTeamMembers(teams)
+	}
+	if policy.Trust.RestrictsApprovers() {
+		input.Trust.ApproverMembers = test.approverMembers(policy.Trust, pr)
+	}
+	return validator.Run(logger, input), nil
+}
+
+// teamMembers returns lower-cased logins of members per team.
+func (t *Test) teamMembers() map[string]map[string]struct{} {
+	teams := make(map[string]map[string]struct{}, len(t.Members))
+	for team, logins := range t.Members {
+		if !strings.Contains(team, "/") {
+			continue
+		}
+		teams[strings.ToLower(team)] = lowerSet(logins)
+	}
+	return teams
+}
+
+// approverMembers returns lower-cased logins of reviewers belonging to approver teams or approver organizations.
+func (t *Test) approverMembers(trust *Trust, pr *github.PullRequest) map[string]struct{} {
+	approvers := lowerSet(append(slices.Clone(trust.ApproverTeams), trust.ApproverOrgs...))
+	members := map[string]struct{}{}
+	for team, logins := range t.Members {
+		if _, ok := approvers[strings.ToLower(team)]; !ok {
+			continue
+		}
+		for _, login := range logins {
+			login = strings.ToLower(login)
+			for reviewer, user := range pr.Approvers {
+				if !user.IsApp && strings.ToLower(reviewer) == login {
+					members[login] = struct{}{}
+				}
+			}
+			for reviewer, user := range pr.ChangesRequesters {
+				if !user.IsApp && strings.ToLower(reviewer) == login {
+					members[login] = struct{}{}
+				}
+			}
+		}
+	}
+	return members
+}
+
+func (p *TestPullRequest) pullRequest(baseRef string) *github.PullRequest {
+	pr := &github.PullRequest{
+		HeadSHA:           "head",
+		BaseRef:           baseRef,
+		Approvers:         testUsers(p.Approvers),
+		ChangesRequesters: testUsers(p.ChangesRequesters),
+		Commits:           make([]*github.Commit, len(p.Commits)),
+	}
+	for i, commit := range p.Commits {
+		pr.Commits[i] = commit.commit(i)
+	}
+	return pr
+}
+
+func testUsers(users []*TestUser) map[string]*github.User {
+	m := make(map[string]*github.User, len(users))
+	for _, user := range users {
+		m[user.Login] = &github.User{Login: user.Login, IsApp: user.IsApp}
+	}
+	return m
+}
+
+func (c *TestCommit) commit(i int) *github.Commit {
+	commit := &github.Commit{
+		SHA: "commit" + strconv.Itoa(i),
+	}
+	if c.Committer != "" {
+		commit.Committer = &github.User{Login: c.Committer, IsApp: c.IsApp}
+	}
+	if c.Author != "" {
+		commit.Author = &github.User{Login: c.Author}
+	}
+	state := strings.ToUpper(c.Signature)
+	if state == "" {
+		state = "VALID"
+	}
+	if state != signatureUnsigned {
+		commit.Signature = &v4.Signature{
+			IsValid: state == "VALID",
+			State:   state,
+		}
+	}
+	return commit
+}
+
+// compare returns an error if the result isn't the expected one.
+func (w *TestWant) compare(result *validation.Result) error {
+	if result.Error != "" {
+		return fmt.Errorf("validation failed: %s", result.Error)
+	}
+	var errs []error
+	if result.State != w.State {
+		errs = append(errs, fmt.Errorf("state is %s, want %s", result.State, w.State))
+	}
+	if w.Reasons != nil {
+		if reasons := result.Reasons(); !slices.Equal(reasons, w.Reasons) {
+			errs = append(errs, fmt.Errorf("reasons are %q, want %q", reasons, w.Reasons))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func lowerSet(s []string) map[string]struct{} {
+	m := make(map[string]struct{}, len(s))
+	for _, v := range s {
+		m[strings.ToLower(v)] = struct{}{}
+	}
+	return m
+}
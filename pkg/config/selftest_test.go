@@ -0,0 +1,145 @@
+package config_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+func TestConfig_tests(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	tests := []struct {
+		name    string
+		cfg     string
+		wantErr string
+	}{
+		{
+			name: "pass",
+			cfg: `
+app_id: 1
+installation_id: 2
+trust:
+  trusted_apps: [renovate]
+repositories:
+  - repositories: [suzuki-shunsuke/strict]
+    trust: {}
+    required_approvals: 2
+  - repositories: [suzuki-shunsuke/restricted]
+    trust:
+      approver_teams: [suzuki-shunsuke/maintainers]
+tests:
+  - name: approved
+    repo: suzuki-shunsuke/foo
+    pull_request:
+      approvers: [{login: octocat}]
+      commits:
+        - committer: suzuki-shunsuke
+    want:
+      state: approved
+  - name: self-approval
+    repo: suzuki-shunsuke/foo
+    pull_request:
+      approvers: [{login: octocat}]
+      commits:
+        - committer: octocat
+    want:
+      state: require_two_approvals
+      reasons: [self-approval]
+  - name: invalid signature
+    repo: suzuki-shunsuke/foo
+    pull_request:
+      approvers: [{login: octocat}]
+      commits:
+        - committer: suzuki-shunsuke
+          signature: INVALID
+    want:
+      state: require_two_approvals
+      reasons: [unsigned commits]
+  - name: the repository requires two approvals
+    repo: suzuki-shunsuke/strict
+    pull_request:
+      approvers: [{login: octocat}]
+      commits:
+        - committer: suzuki-shunsuke
+    want:
+      state: require_two_approvals
+  - name: approvals from non members are ignored
+    repo: suzuki-shunsuke/restricted
+    members:
+      suzuki-shunsuke/maintainers: [octocat]
+    pull_request:
+      approvers: [{login: octocat}, {login: mallory}]
+      commits:
+        - committer: mallory
+    want:
+      state: approved
+`,
+		},
+		{
+			name: "fail",
+			cfg: `
+app_id: 1
+installation_id: 2
+tests:
+  - name: untrusted app
+    repo: suzuki-shunsuke/foo
+    pull_request:
+      approvers: [{login: octocat}]
+      commits:
+        - committer: malicious-app
+          is_app: true
+    want:
+      state: approved
+`,
+			wantErr: `test "untrusted app" failed: state is require_two_approvals, want approved`,
+		},
+		{
+			name: "wrong reasons",
+			cfg: `
+app_id: 1
+installation_id: 2
+tests:
+  - repo: suzuki-shunsuke/foo
+    pull_request:
+      commits:
+        - committer: suzuki-shunsuke
+    want:
+      state: no_approval
+      reasons: [self-approval]
+`,
+			wantErr: `test tests[0] failed: reasons are [], want ["self-approval"]`,
+		},
+		{
+			name: "invalid state",
+			cfg: `
+app_id: 1
+installation_id: 2
+tests:
+  - repo: suzuki-shunsuke/foo
+    pull_request: {}
+    want:
+      state: pending
+`,
+			wantErr: "test tests[0]: want.state must be one of",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := config.Parse(&config.Config{}, []byte(tt.cfg))
+			if err != nil {
+				if tt.wantErr == "" {
+					t.Fatal(err)
+				}
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error %q must contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if tt.wantErr != "" {
+				t.Fatal("error must be returned")
+			}
+		})
+	}
+}
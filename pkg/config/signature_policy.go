@@ -0,0 +1,64 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SignaturePolicy restricts commit signatures.
+// If it isn't set, commits with valid signatures are trusted.
+// If accepted_states is empty, only VALID is accepted.
+// Users not listed in allowed_keys can sign commits with any key.
+type SignaturePolicy struct {
+	AcceptedStates []string            `json:"accepted_states,omitempty" yaml:"accepted_states"`
+	Types          []string            `json:"types,omitempty" yaml:"types"`
+	AllowedKeys    map[string][]string `json:"allowed_keys,omitempty" yaml:"allowed_keys"`
+}
+
+// signatureStates are GitSignatureState of GitHub GraphQL API.
+// https://docs.github.com/en/graphql/reference/enums#gitsignaturestate
+var signatureStates = []string{ //nolint:gochecknoglobals
+	"BAD_CERT",
+	"BAD_EMAIL",
+	"EXPIRED_KEY",
+	"GPGVERIFY_ERROR",
+	"GPGVERIFY_UNAVAILABLE",
+	"INVALID",
+	"MALFORMED_SIG",
+	"NOT_SIGNING_KEY",
+	"NO_USER",
+	"OCSP_ERROR",
+	"OCSP_PENDING",
+	"OCSP_REVOKED",
+	"UNKNOWN_KEY",
+	"UNKNOWN_SIG_TYPE",
+	"UNSIGNED",
+	"UNVERIFIED_EMAIL",
+	"VALID",
+}
+
+var signatureTypes = []string{"gpg", "ssh", "x509"} //nolint:gochecknoglobals
+
+func (p *SignaturePolicy) Validate() error {
+	for _, state := range p.AcceptedStates {
+		if !slices.Contains(signatureStates, state) {
+			return fmt.Errorf("accepted_states contains an unknown signature state: %q", state)
+		}
+	}
+	for _, typ := range p.Types {
+		if !slices.Contains(signatureTypes, typ) {
+			return fmt.Errorf("types contains an unknown signature type: %q. Supported types are gpg, ssh, and x509", typ)
+		}
+	}
+	logins := make([]string, 0, len(p.AllowedKeys))
+	for login, keys := range p.AllowedKeys {
+		if len(keys) == 0 {
+			return fmt.Errorf("allowed_keys of %s is empty", login)
+		}
+		logins = append(logins, login)
+	}
+	if err := validateLoginNames(logins, "allowed_keys"); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+func TestSignaturePolicy_Validate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		input   *config.SignaturePolicy
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: &config.SignaturePolicy{},
+		},
+		{
+			name: "valid",
+			input: &config.SignaturePolicy{
+				AcceptedStates: []string{"VALID", "UNVERIFIED_EMAIL"},
+				Types:          []string{"gpg", "ssh"},
+				AllowedKeys: map[string][]string{
+					"octocat": {"SHA256:abc"},
+				},
+			},
+		},
+		{
+			name: "unknown state",
+			input: &config.SignaturePolicy{
+				AcceptedStates: []string{"valid"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown type",
+			input: &config.SignaturePolicy{
+				Types: []string{"smime"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty allowed keys",
+			input: &config.SignaturePolicy{
+				AllowedKeys: map[string][]string{
+					"octocat": {},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.input.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("SignaturePolicy.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+	"gopkg.in/yaml.v3"
+)
+
+// Schemes of object storage URLs.
+const (
+	SchemeS3  = "s3"
+	SchemeGCS = "gs"
+)
+
+// ObjectPollInterval is the interval to check if the config in object storage is changed.
+// Objects are downloaded only when their ETags are changed.
+const ObjectPollInterval = time.Minute
+
+// ErrNotModified is returned by ObjectStorage if the object isn't modified.
+var ErrNotModified = errors.New("the object isn't modified")
+
+// ObjectStorage gets objects from object storage such as Amazon S3 and Google Cloud Storage.
+type ObjectStorage interface {
+	// GetObject returns the object and its ETag.
+	// If etag isn't empty and matches the ETag of the object, it returns ErrNotModified.
+	GetObject(ctx context.Context, bucket, key, etag string) ([]byte, string, error)
+}
+
+// ObjectURL is the URL of the config in object storage.
+type ObjectURL struct {
+	Scheme string
+	Bucket string
+	Key    string
+}
+
+func (u *ObjectURL) String() string {
+	return u.Scheme + "://" + u.Bucket + "/" + u.Key
+}
+
+// ParseObjectURL parses the URL like s3://<bucket>/<key> and gs://<bucket>/<key>.
+// If s isn't a URL of object storage, it returns nil.
+func ParseObjectURL(s string) (*ObjectURL, error) {
+	scheme, path, ok := strings.Cut(s, "://")
+	if !ok || (scheme != SchemeS3 && scheme != SchemeGCS) {
+		return nil, nil //nolint:nilnil
+	}
+	bucket, key, _ := strings.Cut(path, "/")
+	if bucket == "" || key == "" {
+		return nil, fmt.Errorf("the URL must be in the format %s://<bucket>/<key>: %q", scheme, s)
+	}
+	return &ObjectURL{
+		Scheme: scheme,
+		Bucket: bucket,
+		Key:    key,
+	}, nil
+}
+
+// ObjectURLFromEnv returns the URL of the config in object storage.
+// If CONFIG is set or CONFIG_FILE isn't a URL of object storage, it returns nil.
+func ObjectURLFromEnv(getEnv func(string) string) (*ObjectURL, error) {
+	if getEnv("CONFIG") != "" {
+		return nil, nil //nolint:nilnil
+	}
+	return ParseObjectURL(getEnv("CONFIG_FILE"))
+}
+
+// Source reads the raw config.
+// If the config is in object storage, the ETag of the object is kept to download it only when it's changed.
+type Source struct {
+	url     *ObjectURL
+	storage ObjectStorage
+	getEnv  func(string) string
+	mu      sync.Mutex
+	etag    string
+	data    []byte
+}
+
+// NewSource returns a source of the config.
+// If url is nil, the config is read from CONFIG or CONFIG_FILE, which are got by getEnv.
+func NewSource(url *ObjectURL, storage ObjectStorage, getEnv func(string) string) *Source {
+	return &Source{
+		url:     url,
+		storage: storage,
+		getEnv:  getEnv,
+	}
+}
+
+// IsObject reports whether the config is read from object storage.
+func (s *Source) IsObject() bool {
+	return s.url != nil
+}
+
+// IsFile reports whether the config is read from the local file CONFIG_FILE.
+func (s *Source) IsFile() bool {
+	return s.url == nil && s.getEnv("CONFIG") == "" && s.getEnv("CONFIG_FILE") != ""
+}
+
+// Read reads, parses, and initializes the config.
+// The config in object storage can't include other files.
+func (s *Source) Read(ctx context.Context, cfg *Config) error {
+	l, root, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+	return decode(cfg, l, root)
+}
+
+// load reads the config and files it refers to without initializing it.
+func (s *Source) load(ctx context.Context) (*loader, *yaml.Node, error) {
+	l := newLoader(s.getEnv)
+	if s.url != nil {
+		data, err := s.getObject(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		root, err := l.load(s.url.String(), "", data)
+		return l, root, err
+	}
+	if cfgStr := s.getEnv("CONFIG"); cfgStr != "" {
+		root, err := l.load("CONFIG", ".", []byte(cfgStr))
+		return l, root, err
+	}
+	if cfgPath := s.getEnv("CONFIG_FILE"); cfgPath != "" {
+		root, err := l.loadFile(cfgPath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("load the config: %w", slogerr.With(err, "config_file", cfgPath))
+		}
+		return l, root, nil
+	}
+	return nil, nil, errors.New("CONFIG or CONFIG_FILE environment variable is required")
+}
+
+func (s *Source) getObject(ctx context.Context) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, etag, err := s.storage.GetObject(ctx, s.url.Bucket, s.url.Key, s.etag)
+	if errors.Is(err, ErrNotModified) {
+		return s.data, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get the config from object storage: %w", slogerr.With(err, "config_file", s.url.String()))
+	}
+	s.etag = etag
+	s.data = data
+	return data, nil
+}
+
+// Reload reads the config and returns the new config if it's changed from old.
+// If it isn't changed, it returns nil.
+// Settings used to create the GitHub client and read secrets can't be changed without restarting the app.
+// The config is initialized only if the hash of the raw config is changed, because initializing the config runs config tests.
+func (s *Source) Reload(ctx context.Context, old *Config) (*Config, error) {
+	l, root, err := s.load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("read the config: %w", err)
+	}
+	if l.sum() == old.Hash {
+		return nil, nil //nolint:nilnil
+	}
+	cfg := &Config{}
+	if err := decode(cfg, l, root); err != nil {
+		return nil, fmt.Errorf("read the config: %w", err)
+	}
+	if cfg.AppID != old.AppID || cfg.InstallationID != old.InstallationID {
+		return nil, errors.New("app_id and installation_id can't be changed without restarting the app")
+	}
+	return cfg, nil
+}
@@ -0,0 +1,125 @@
+package config_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+func TestParseObjectURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		url     string
+		want    *config.ObjectURL
+		wantErr bool
+	}{
+		{
+			name: "s3",
+			url:  "s3://bucket/path/config.yaml",
+			want: &config.ObjectURL{Scheme: "s3", Bucket: "bucket", Key: "path/config.yaml"},
+		},
+		{
+			name: "gcs",
+			url:  "gs://bucket/config.yaml",
+			want: &config.ObjectURL{Scheme: "gs", Bucket: "bucket", Key: "config.yaml"},
+		},
+		{
+			name: "file path",
+			url:  "config.yaml",
+		},
+		{
+			name: "unknown scheme",
+			url:  "https://example.com/config.yaml",
+		},
+		{
+			name:    "no key",
+			url:     "s3://bucket",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := config.ParseObjectURL(tt.url)
+			if err != nil {
+				if !tt.wantErr {
+					t.Fatal(err)
+				}
+				return
+			}
+			if tt.wantErr {
+				t.Fatal("error must be returned")
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ParseObjectURL() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+type mockStorage struct {
+	data      string
+	etag      string
+	downloads int
+}
+
+func (m *mockStorage) GetObject(_ context.Context, _, _, etag string) ([]byte, string, error) {
+	if etag == m.etag {
+		return nil, "", config.ErrNotModified
+	}
+	m.downloads++
+	return []byte(m.data), m.etag, nil
+}
+
+func TestSource_Reload(t *testing.T) {
+	t.Parallel()
+	storage := &mockStorage{
+		data: "app_id: 1\ninstallation_id: 2\n",
+		etag: "1",
+	}
+	src := config.NewSource(&config.ObjectURL{Scheme: "s3", Bucket: "bucket", Key: "config.yaml"}, storage, func(string) string { return "" })
+	cfg := &config.Config{}
+	if err := src.Read(t.Context(), cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	// not modified
+	newCfg, err := src.Reload(t.Context(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newCfg != nil {
+		t.Fatal("the config must not be changed")
+	}
+	if storage.downloads != 1 {
+		t.Fatalf("the object must not be downloaded again: %d", storage.downloads)
+	}
+
+	// invalid config
+	storage.data = "app_id: 1\ninstallation_id: 2\nrequired_approvals: -1\n"
+	storage.etag = "2"
+	if _, err := src.Reload(t.Context(), cfg); err == nil {
+		t.Fatal("error must be returned")
+	}
+
+	// app_id is changed
+	storage.data = "app_id: 3\ninstallation_id: 2\n"
+	storage.etag = "3"
+	if _, err := src.Reload(t.Context(), cfg); err == nil {
+		t.Fatal("error must be returned")
+	}
+
+	// changed
+	storage.data = "app_id: 1\ninstallation_id: 2\nrequired_approvals: 2\n"
+	storage.etag = "4"
+	newCfg, err = src.Reload(t.Context(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if newCfg == nil || newCfg.Hash != config.Hash([]byte(storage.data)) {
+		t.Fatal("the config must be reloaded")
+	}
+}
@@ -23,6 +23,14 @@ var (
 	templateApproved []byte
 	//go:embed templates/error.md
 	templateError []byte
+	//go:embed templates/code_owners.md
+	templateCodeOwners []byte
+	//go:embed templates/changes_requested.md
+	templateChangesRequested []byte
+	//go:embed templates/sensitive_paths.md
+	templateSensitivePaths []byte
+	//go:embed templates/invalid_repo_config.md
+	templateInvalidRepoConfig []byte
 )
 
 const TmplKeyError = "error"
@@ -31,9 +39,13 @@ func (c *Config) initTemplates() error {
 	defaultTemplates := map[string]string{
 		"footer":                string(templateFooter),
 		"settings":              string(templateSettings),
+		"code_owners":           string(templateCodeOwners),
+		"sensitive_paths":       string(templateSensitivePaths),
 		"approved":              string(templateApproved),
 		"no_approval":           string(templateNoApproval),
 		"require_two_approvals": string(templateRequireTwoApprovals),
+		"changes_requested":     string(templateChangesRequested),
+		"invalid_repo_config":   string(templateInvalidRepoConfig),
 		TmplKeyError:            string(templateError),
 	}
 	if c.Templates == nil {
@@ -57,6 +69,8 @@ func (c *Config) initTemplates() error {
 		"no_approval",
 		"approved",
 		"require_two_approvals",
+		"changes_requested",
+		"invalid_repo_config",
 		TmplKeyError,
 	}
 	templates := make(map[string]*template.Template, len(keys))
@@ -73,11 +87,17 @@ func (c *Config) initTemplates() error {
 }
 
 func (c *Config) testTemplate() error {
-	// TODO add test cases
-	result := &validation.Result{}
+	results, err := c.runTests()
+	if err != nil {
+		return err
+	}
+	// Templates are also rendered with results of test cases
+	results = append(results, &validation.Result{})
 	for key, tpl := range c.BuiltTemplates {
-		if err := tpl.Execute(io.Discard, result); err != nil {
-			return fmt.Errorf("test template %s: %w", key, err)
+		for _, result := range results {
+			if err := tpl.Execute(io.Discard, result); err != nil {
+				return fmt.Errorf("test template %s: %w", key, err)
+			}
 		}
 	}
 	return nil
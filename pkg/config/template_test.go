@@ -4,6 +4,7 @@ package config_test
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
@@ -23,13 +24,15 @@ func TestTemplates(t *testing.T) {
 		{
 			name: "two approvals",
 			result: &validation.Result{
-				State:     validation.StateApproved,
-				Approvers: []string{"user1", "user2"},
-				Version:   "v0.0.1",
+				State:             validation.StateApproved,
+				Approvers:         []string{"user1", "user2"},
+				ApprovalCount:     2,
+				RequiredApprovals: 1,
+				Version:           "v0.0.1",
 			},
 			template: "approved",
 			wantErr:  false,
-			wantText: `The pull request has been approved.
+			wantText: `The pull request has been approved (2 approvals, 1 required).
 
 Approvers:
 
@@ -53,16 +56,18 @@ Untrusted Machine Users: Nothing
 		{
 			name: "one approval",
 			result: &validation.Result{
-				State:       validation.StateApproved,
-				Approvers:   []string{"user1"},
-				TrustedApps: []string{"dependabot[bot]", "renovate[bot]"},
+				State:             validation.StateApproved,
+				Approvers:         []string{"user1"},
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+				TrustedApps:       []string{"dependabot[bot]", "renovate[bot]"},
 
 				UntrustedMachineUsers: []string{"*-bot"},
 				RequestID:             "req-12345",
 			},
 			template: "approved",
 			wantErr:  false,
-			wantText: `The pull request has been approved.
+			wantText: `The pull request has been approved (1 approval, 1 required).
 
 Approvers:
 
@@ -119,11 +124,119 @@ Untrusted Machine Users:
 		{
 			name: "no approval",
 			result: &validation.Result{
-				State: validation.StateApprovalIsRequired,
+				State:             validation.StateApprovalIsRequired,
+				RequiredApprovals: 1,
 			},
 			template: "no_approval",
 			wantText: `This commit has no approvals.
-Approvals are required.
+Approvals are required (0 approvals, 1 required).
+
+## Settings
+
+Trusted Apps: Nothing
+
+Untrusted Machine Users: Nothing
+
+---
+
+[This check is created by Validate PR Review App](https://github.com/suzuki-shunsuke/validate-pr-review-app).
+
+- Version: unknown
+- Request ID: unknown
+`,
+		},
+		{
+			name: "no approval with missing code owners",
+			result: &validation.Result{
+				State:             validation.StateApprovalIsRequired,
+				RequiredApprovals: 1,
+				MissingCodeOwners: []*validation.CodeOwnerRule{
+					{
+						Owners: []string{"@org/infra", "@octocat"},
+						Paths:  []string{"terraform/main.tf", "terraform/variables.tf"},
+					},
+				},
+			},
+			template: "no_approval",
+			wantText: `This commit has no approvals.
+Approvals are required (0 approvals, 1 required).
+
+## Code owner approvals are required
+
+The following code owners haven't approved this pull request yet.
+Approvals from code owners who committed, authored, or co-authored commits of this pull request are ignored.
+
+- ` + "`@org/infra`, `@octocat`: `terraform/main.tf` `terraform/variables.tf`" + `
+
+## Settings
+
+Trusted Apps: Nothing
+
+Untrusted Machine Users: Nothing
+
+---
+
+[This check is created by Validate PR Review App](https://github.com/suzuki-shunsuke/validate-pr-review-app).
+
+- Version: unknown
+- Request ID: unknown
+`,
+		},
+		{
+			name: "approved with ignored lines of CODEOWNERS",
+			result: &validation.Result{
+				State:             validation.StateApproved,
+				Approvers:         []string{"user1"},
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+				IgnoredCodeOwnersLines: []*validation.IgnoredCodeOwnersLine{
+					{Line: 3, Pattern: "!docs/"},
+				},
+			},
+			template: "approved",
+			wantText: `The pull request has been approved (1 approval, 1 required).
+
+Approvers:
+
+- user1
+
+## :warning: Some lines of CODEOWNERS are ignored
+
+The following lines of CODEOWNERS are ignored because their patterns aren't supported.
+
+- Line 3: ` + "`!docs/`" + `
+
+## Settings
+
+Trusted Apps: Nothing
+
+Untrusted Machine Users: Nothing
+
+---
+
+[This check is created by Validate PR Review App](https://github.com/suzuki-shunsuke/validate-pr-review-app).
+
+- Version: unknown
+- Request ID: unknown
+`,
+		},
+		{
+			name: "no approval with sensitive files",
+			result: &validation.Result{
+				State:             validation.StateApprovalIsRequired,
+				RequiredApprovals: 2,
+				SensitiveFiles:    []string{".github/workflows/test.yaml", "CODEOWNERS"},
+			},
+			template: "no_approval",
+			wantText: `This commit has no approvals.
+Approvals are required (0 approvals, 2 required).
+
+## Sensitive files are changed
+
+This pull request changes the following sensitive files, so 2 approvals from people who didn't commit, author, or co-author commits of this pull request are required.
+
+- ` + "`.github/workflows/test.yaml`" + `
+- ` + "`CODEOWNERS`" + `
 
 ## Settings
 
@@ -142,9 +255,11 @@ Untrusted Machine Users: Nothing
 		{
 			name: "require two approvals",
 			result: &validation.Result{
-				State:         validation.StateTwoApprovalsAreRequired,
-				SelfApprovers: map[string]struct{}{"foo": {}},
-				Approvers:     []string{"user1"},
+				State:             validation.StateTwoApprovalsAreRequired,
+				SelfApprovers:     map[string][]string{"foo": {"author", "co-author"}},
+				Approvers:         []string{"user1"},
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
 				IgnoredApprovers: []*github.IgnoredApproval{
 					{
 						Login:                  "foo-bot",
@@ -161,19 +276,19 @@ Untrusted Machine Users: Nothing
 				},
 			},
 			template: "require_two_approvals",
-			wantText: `This pull request requires two approvals because:
+			wantText: `This pull request requires more approvals (1 approval, 2 required).
 
-The following approvers have self-approved this pull request by pushing commits:
+The following approvers have self-approved this pull request by contributing to or pushing commits:
 
-- ` + "`foo`" + `
+- ` + "`foo`" + `: author, co-author
 
-The following commits are untrusted, so two approvals are required.
+The following commits are untrusted, so 2 approvals are required.
 
 - xxx foo-bot The committer is an untrusted machine user.
 
 ## :warning: Some approvals are ignored
 
-Approvals from GitHub Apps and Untrusted Machine Users are ignored.
+Approvals from GitHub Apps, Untrusted Machine Users, and users outside Approver Teams and Approver Organizations are ignored.
 
 Approvals from the following approvers are ignored:
 - foo-bot Untrusted Machine User
@@ -188,6 +303,133 @@ Untrusted Machine Users:
 
 [This check is created by Validate PR Review App](https://github.com/suzuki-shunsuke/validate-pr-review-app).
 
+- Version: unknown
+- Request ID: unknown
+`,
+		},
+		{
+			name: "require two approvals with non-member approvals",
+			result: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				Approvers:         []string{"user1"},
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
+				IgnoredApprovers: []*github.IgnoredApproval{
+					{
+						Login:       "outsider",
+						IsNotMember: true,
+					},
+				},
+				ApproverTeams: []string{"org/reviewers"},
+				ApproverOrgs:  []string{"org"},
+			},
+			template: "require_two_approvals",
+			wantText: `This pull request requires more approvals (1 approval, 2 required).
+
+
+
+
+## :warning: Some approvals are ignored
+
+Approvals from GitHub Apps, Untrusted Machine Users, and users outside Approver Teams and Approver Organizations are ignored.
+
+Approvals from the following approvers are ignored:
+- outsider Not a member of Approver Teams and Approver Organizations
+
+## Settings
+
+Trusted Apps: Nothing
+
+Untrusted Machine Users: Nothing
+
+Approver Teams:
+- org/reviewers
+
+Approver Organizations:
+- org
+
+---
+
+[This check is created by Validate PR Review App](https://github.com/suzuki-shunsuke/validate-pr-review-app).
+
+- Version: unknown
+- Request ID: unknown
+`,
+		},
+		{
+			name: "changes requested",
+			result: &validation.Result{
+				State:             validation.StateChangesRequested,
+				Approvers:         []string{"user1", "user2"},
+				ChangesRequesters: []string{"user3"},
+				ApprovalCount:     2,
+				RequiredApprovals: 1,
+			},
+			template: "changes_requested",
+			wantText: `This pull request can't be approved because the following reviewers have requested changes.
+Ask them to approve this pull request, or dismiss their reviews.
+
+- ` + "`user3`" + `
+
+The following approvals are valid, but they don't override the requested changes (2 approvals, 1 required):
+
+- user1
+- user2
+
+## Settings
+
+Trusted Apps: Nothing
+
+Untrusted Machine Users: Nothing
+
+---
+
+[This check is created by Validate PR Review App](https://github.com/suzuki-shunsuke/validate-pr-review-app).
+
+- Version: unknown
+- Request ID: unknown
+`,
+		},
+		{
+			name: "require two approvals with a force-push by an approver",
+			result: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				SelfApprovers:     map[string][]string{"foo": {"pusher"}},
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
+				SelfApprovalPushes: []*github.Push{
+					{
+						Pusher:      &github.User{Login: "foo"},
+						BeforeSHA:   "aaa",
+						AfterSHA:    "bbb",
+						PushedAt:    time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+						ForcePushed: true,
+					},
+				},
+			},
+			template: "require_two_approvals",
+			wantText: `This pull request requires more approvals (1 approval, 2 required).
+
+The following approvers have self-approved this pull request by contributing to or pushing commits:
+
+- ` + "`foo`" + `: pusher
+
+The following pushes were made by approvers:
+
+- ` + "`foo`" + ` force-pushed aaa...bbb at 2025-01-02T03:04:05Z
+
+
+
+## Settings
+
+Trusted Apps: Nothing
+
+Untrusted Machine Users: Nothing
+
+---
+
+[This check is created by Validate PR Review App](https://github.com/suzuki-shunsuke/validate-pr-review-app).
+
 - Version: unknown
 - Request ID: unknown
 `,
@@ -197,6 +439,8 @@ Untrusted Machine Users:
 			result: &validation.Result{
 				State:                      validation.StateApproved,
 				Approvers:                  []string{"user1"},
+				ApprovalCount:              1,
+				RequiredApprovals:          1,
 				AllowUnsignedCommits:       true,
 				UnsignedCommitApps:         []string{"renovate", "dependabot"},
 				UnsignedCommitMachineUsers: []string{"ci-user"},
@@ -204,7 +448,7 @@ Untrusted Machine Users:
 				RequestID:                  "req-insecure",
 			},
 			template: "approved",
-			wantText: `The pull request has been approved.
+			wantText: `The pull request has been approved (1 approval, 1 required).
 
 Approvers:
 
@@ -237,12 +481,14 @@ Untrusted Machine Users: Nothing
 			result: &validation.Result{
 				State:              validation.StateApproved,
 				Approvers:          []string{"user1"},
+				ApprovalCount:      1,
+				RequiredApprovals:  1,
 				UnsignedCommitApps: []string{"deploy-bot"},
 				Version:            "v0.1.0",
 				RequestID:          "req-partial",
 			},
 			template: "approved",
-			wantText: `The pull request has been approved.
+			wantText: `The pull request has been approved (1 approval, 1 required).
 
 Approvers:
 
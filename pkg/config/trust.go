@@ -2,24 +2,51 @@ package config
 
 import (
 	"fmt"
-	"path"
 	"strings"
 )
 
 type Trust struct {
-	UntrustedMachineUsers []string            `json:"untrusted_machine_users,omitempty" yaml:"untrusted_machine_users"`
-	TrustedApps           []string            `json:"trusted_apps,omitempty" yaml:"trusted_apps"`
-	UniqueTrustedApps     map[string]struct{} `json:"-" yaml:"-"`
+	UntrustedMachineUsers        []string            `json:"untrusted_machine_users,omitempty" yaml:"untrusted_machine_users"`
+	TrustedApps                  []string            `json:"trusted_apps,omitempty" yaml:"trusted_apps"`
+	ApproverTeams                []string            `json:"approver_teams,omitempty" yaml:"approver_teams"`
+	ApproverOrgs                 []string            `json:"approver_orgs,omitempty" yaml:"approver_orgs"`
+	UniqueTrustedApps            map[string]struct{} `json:"-" yaml:"-"`
+	TrustedAppsMatcher           *Matcher            `json:"-" yaml:"-"`
+	UntrustedMachineUsersMatcher *Matcher            `json:"-" yaml:"-"`
 }
 
+// Validate validates the trust config.
+// Approver teams are normalized so that they can be written like `@org/team` as in untrusted_machine_users.
 func (t *Trust) Validate() error {
-	if err := validateLoginNames(t.TrustedApps, "trusted_apps"); err != nil {
+	if _, err := NewAppMatcher(t.TrustedApps); err != nil {
+		return fmt.Errorf("trusted_apps: %w", err)
+	}
+	if _, err := NewUserMatcher(t.UntrustedMachineUsers); err != nil {
+		return fmt.Errorf("untrusted_machine_users: %w", err)
+	}
+	if err := validateLoginNames(t.ApproverOrgs, "approver_orgs"); err != nil {
+		return err
+	}
+	if err := validateLoginNames(t.ApproverTeams, "approver_teams"); err != nil {
 		return err
 	}
+	for i, team := range t.ApproverTeams {
+		org, slug, ok := strings.Cut(strings.TrimPrefix(team, "@"), "/")
+		if !ok || org == "" || slug == "" || strings.Contains(slug, "/") {
+			return fmt.Errorf("approver_teams must be in the format <organization>/<team slug> or @<organization>/<team slug>: %q", team)
+		}
+		t.ApproverTeams[i] = org + "/" + slug
+	}
 	return nil
 }
 
-func (t *Trust) Init() {
+// RestrictsApprovers reports whether approvers are restricted to members of approver teams and organizations.
+func (t *Trust) RestrictsApprovers() bool {
+	return len(t.ApproverTeams) > 0 || len(t.ApproverOrgs) > 0
+}
+
+// Init sets the default trusted apps and compiles patterns.
+func (t *Trust) Init() error {
 	if t.TrustedApps == nil {
 		t.TrustedApps = []string{
 			"dependabot[bot]",
@@ -28,7 +55,7 @@ func (t *Trust) Init() {
 	} else {
 		for i, v := range t.TrustedApps {
 			// Append [bot] suffix if not exists
-			if !strings.HasSuffix(v, "[bot]") {
+			if !strings.HasSuffix(v, "[bot]") && !isRegexpPattern(v) {
 				t.TrustedApps[i] = v + "[bot]"
 			}
 		}
@@ -40,14 +67,15 @@ func (t *Trust) Init() {
 		}
 		t.UniqueTrustedApps[app] = struct{}{}
 	}
-}
-
-func (c *Config) testUntrustedMachineUsers() error {
-	for _, pattern := range c.Trust.UntrustedMachineUsers {
-		p := strings.TrimPrefix(pattern, "!")
-		if _, err := path.Match(p, "foo"); err != nil {
-			return fmt.Errorf("invalid untrusted machine user pattern %q: %w", pattern, err)
-		}
+	apps, err := NewAppMatcher(t.TrustedApps)
+	if err != nil {
+		return fmt.Errorf("trusted_apps: %w", err)
+	}
+	users, err := NewUserMatcher(t.UntrustedMachineUsers)
+	if err != nil {
+		return fmt.Errorf("untrusted_machine_users: %w", err)
 	}
+	t.TrustedAppsMatcher = apps
+	t.UntrustedMachineUsersMatcher = users
 	return nil
 }
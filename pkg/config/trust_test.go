@@ -3,15 +3,17 @@ package config_test
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
 )
 
 func TestTrust_Validate(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
-		name    string
-		trust   *config.Trust
-		wantErr bool
+		name      string
+		trust     *config.Trust
+		wantTeams []string
+		wantErr   bool
 	}{
 		{
 			name: "valid entries",
@@ -31,10 +33,58 @@ func TestTrust_Validate(t *testing.T) {
 			wantErr: true,
 		},
 		{
-			name: "invalid trusted app with asterisk",
+			name: "trusted app glob",
 			trust: &config.Trust{
 				TrustedApps: []string{"renovate*"},
 			},
+		},
+		{
+			name: "invalid untrusted machine user pattern",
+			trust: &config.Trust{
+				UntrustedMachineUsers: []string{"bot-[a"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid approver teams and organizations",
+			trust: &config.Trust{
+				ApproverTeams: []string{"suzuki-shunsuke/reviewers"},
+				ApproverOrgs:  []string{"suzuki-shunsuke"},
+			},
+		},
+		{
+			name: "approver team with @",
+			trust: &config.Trust{
+				ApproverTeams: []string{"@suzuki-shunsuke/reviewers"},
+			},
+			wantTeams: []string{"suzuki-shunsuke/reviewers"},
+		},
+		{
+			name: "approver team with @ but without organization",
+			trust: &config.Trust{
+				ApproverTeams: []string{"@reviewers"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "approver team without organization",
+			trust: &config.Trust{
+				ApproverTeams: []string{"reviewers"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "approver team with nested slash",
+			trust: &config.Trust{
+				ApproverTeams: []string{"org/parent/child"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid approver organization with asterisk",
+			trust: &config.Trust{
+				ApproverOrgs: []string{"org-*"},
+			},
 			wantErr: true,
 		},
 	}
@@ -46,6 +96,11 @@ func TestTrust_Validate(t *testing.T) {
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Trust.Validate() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if tt.wantTeams != nil {
+				if diff := cmp.Diff(tt.wantTeams, tt.trust.ApproverTeams); diff != "" {
+					t.Errorf("ApproverTeams mismatch (-want +got):\n%s", diff)
+				}
+			}
 		})
 	}
 }
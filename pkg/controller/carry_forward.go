@@ -14,8 +14,8 @@ import (
 // carryForwardCheck handles pull_request.synchronize events.
 // When new commits are pushed that are all empty or clean merge commits,
 // carry forward the validation result from the most recent reviewed commit.
-func (c *Controller) carryForwardCheck(ctx context.Context, logger *slog.Logger, ev *Event, trust *config.Trust, insecure *config.Insecure) *validation.Result {
-	pr, err := c.gh.GetPR(ctx, ev.RepoOwner, ev.RepoName, ev.PRNumber)
+func (c *Controller) carryForwardCheck(ctx context.Context, logger *slog.Logger, ev *Event, policy *config.Policy) *validation.Result {
+	pr, err := c.getPR(ctx, ev)
 	if err != nil {
 		return &validation.Result{Error: fmt.Errorf("get a pull request: %w", err).Error()}
 	}
@@ -37,19 +37,9 @@ func (c *Controller) carryForwardCheck(ctx context.Context, logger *slog.Logger,
 	pr.Approvers = approvers
 	c.checkApproverCommits(ctx, logger, ev, pr)
 
-	input := &validation.Input{
-		PR: pr,
-		Trust: &validation.Trust{
-			TrustedApps:           trust.UniqueTrustedApps,
-			UntrustedMachineUsers: trust.UntrustedMachineUsers,
-		},
-	}
-	if insecure != nil {
-		input.Insecure = &validation.Insecure{
-			AllowUnsignedCommits:       insecure.AllowUnsignedCommits != nil && *insecure.AllowUnsignedCommits,
-			UnsignedCommitApps:         toSet(insecure.UnsignedCommitApps),
-			UnsignedCommitMachineUsers: toSet(insecure.UnsignedCommitMachineUsers),
-		}
+	input, err := c.newValidationInput(ctx, logger, ev, pr, policy)
+	if err != nil {
+		return &validation.Result{Error: err.Error()}
 	}
 	result := c.validator.Run(logger, input)
 	result.CarriedForward = true
@@ -18,25 +18,32 @@ func (c *Controller) newCheckRunInput(logger *slog.Logger, ev *Event, result *va
 	result.Version = c.input.Version
 	var conclusion githubv4.CheckConclusionState
 	var title githubv4.String
+	summary := result.ApprovalSummary()
 	switch result.State {
 	case validation.StateApproved:
 		conclusion = githubv4.CheckConclusionStateSuccess
 		if result.CarriedForward {
-			title = githubv4.String("Approved (carried forward)")
+			title = githubv4.String("Approved (carried forward, " + summary + ")")
 		} else {
-			title = githubv4.String("Approved")
+			title = githubv4.String("Approved (" + summary + ")")
 		}
 	case validation.StateApprovalIsRequired:
 		conclusion = githubv4.CheckConclusionStateFailure
-		title = githubv4.String("Approvals are required")
+		title = githubv4.String("Approvals are required (" + summary + ")")
 	case validation.StateTwoApprovalsAreRequired:
 		conclusion = githubv4.CheckConclusionStateFailure
 		reasons := result.Reasons()
 		if len(reasons) > 0 {
-			title = githubv4.String("Two approvals are required (" + strings.Join(reasons, ", ") + ")")
+			title = githubv4.String("More approvals are required (" + summary + ": " + strings.Join(reasons, ", ") + ")")
 		} else {
-			title = githubv4.String("Two approvals are required")
+			title = githubv4.String("More approvals are required (" + summary + ")")
 		}
+	case validation.StateChangesRequested:
+		conclusion = githubv4.CheckConclusionStateFailure
+		title = githubv4.String("Changes are requested by " + strings.Join(result.ChangesRequesters, ", "))
+	case validation.StateInvalidRepoConfig:
+		conclusion = githubv4.CheckConclusionStateFailure
+		title = githubv4.String("The repository config file " + config.RepoFilePath + " is invalid")
 	}
 	if result.Error != "" {
 		conclusion = githubv4.CheckConclusionStateFailure
@@ -44,6 +51,8 @@ func (c *Controller) newCheckRunInput(logger *slog.Logger, ev *Event, result *va
 	}
 	result.TrustedApps = trust.TrustedApps
 	result.UntrustedMachineUsers = trust.UntrustedMachineUsers
+	result.ApproverTeams = trust.ApproverTeams
+	result.ApproverOrgs = trust.ApproverOrgs
 	if insecure != nil {
 		result.AllowUnsignedCommits = insecure.AllowUnsignedCommits != nil && *insecure.AllowUnsignedCommits
 		result.UnsignedCommitApps = insecure.UnsignedCommitApps
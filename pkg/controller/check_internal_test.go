@@ -21,6 +21,7 @@ func TestController_newCheckRunInput(t *testing.T) {
 		"approved":              template.Must(template.New("approved").Parse("PR Approved by {{.Approvers}}")),
 		"no_approval":           template.Must(template.New("no_approval").Parse("No approval found")),
 		"require_two_approvals": template.Must(template.New("require_two_approvals").Parse("Two approvals required")),
+		"changes_requested":     template.Must(template.New("changes_requested").Parse("Changes requested by {{.ChangesRequesters}}")),
 		"error":                 template.Must(template.New("error").Parse("Error: {{.Error}}")),
 	}
 
@@ -48,8 +49,10 @@ func TestController_newCheckRunInput(t *testing.T) {
 				HeadSHA: "abc123",
 			},
 			result: &validation.Result{
-				State:     validation.StateApproved,
-				Approvers: []string{"user1", "user2"},
+				State:             validation.StateApproved,
+				Approvers:         []string{"user1", "user2"},
+				ApprovalCount:     2,
+				RequiredApprovals: 1,
 			},
 			expected: githubv4.CreateCheckRunInput{
 				RepositoryID: githubv4.String("12345"),
@@ -58,7 +61,7 @@ func TestController_newCheckRunInput(t *testing.T) {
 				Status:       &[]githubv4.RequestableCheckStatusState{githubv4.RequestableCheckStatusStateCompleted}[0],
 				Conclusion:   &[]githubv4.CheckConclusionState{githubv4.CheckConclusionStateSuccess}[0],
 				Output: &githubv4.CheckRunOutput{
-					Title:   githubv4.String("Approved"),
+					Title:   githubv4.String("Approved (2 approvals, 1 required)"),
 					Summary: githubv4.String("PR Approved by [user1 user2]"),
 				},
 			},
@@ -79,7 +82,8 @@ func TestController_newCheckRunInput(t *testing.T) {
 				HeadSHA: "abc123",
 			},
 			result: &validation.Result{
-				State: validation.StateApprovalIsRequired,
+				State:             validation.StateApprovalIsRequired,
+				RequiredApprovals: 1,
 			},
 			expected: githubv4.CreateCheckRunInput{
 				RepositoryID: githubv4.String("12345"),
@@ -88,7 +92,7 @@ func TestController_newCheckRunInput(t *testing.T) {
 				Status:       &[]githubv4.RequestableCheckStatusState{githubv4.RequestableCheckStatusStateCompleted}[0],
 				Conclusion:   &[]githubv4.CheckConclusionState{githubv4.CheckConclusionStateFailure}[0],
 				Output: &githubv4.CheckRunOutput{
-					Title:   githubv4.String("Approvals are required"),
+					Title:   githubv4.String("Approvals are required (0 approvals, 1 required)"),
 					Summary: githubv4.String("No approval found"),
 				},
 			},
@@ -109,8 +113,10 @@ func TestController_newCheckRunInput(t *testing.T) {
 				HeadSHA: "abc123",
 			},
 			result: &validation.Result{
-				State:         validation.StateTwoApprovalsAreRequired,
-				SelfApprovers: map[string]struct{}{"committer": {}},
+				State:             validation.StateTwoApprovalsAreRequired,
+				SelfApprovers:     map[string][]string{"committer": {"committer"}},
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
 			},
 			expected: githubv4.CreateCheckRunInput{
 				RepositoryID: githubv4.String("12345"),
@@ -119,7 +125,7 @@ func TestController_newCheckRunInput(t *testing.T) {
 				Status:       &[]githubv4.RequestableCheckStatusState{githubv4.RequestableCheckStatusStateCompleted}[0],
 				Conclusion:   &[]githubv4.CheckConclusionState{githubv4.CheckConclusionStateFailure}[0],
 				Output: &githubv4.CheckRunOutput{
-					Title:   githubv4.String("Two approvals are required (self-approval)"),
+					Title:   githubv4.String("More approvals are required (1 approval, 2 required: self-approval)"),
 					Summary: githubv4.String("Two approvals required"),
 				},
 			},
@@ -140,7 +146,9 @@ func TestController_newCheckRunInput(t *testing.T) {
 				HeadSHA: "abc123",
 			},
 			result: &validation.Result{
-				State: validation.StateTwoApprovalsAreRequired,
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
 				UntrustedCommits: []*github.UntrustedCommit{
 					{
 						Login:       "committer",
@@ -156,7 +164,7 @@ func TestController_newCheckRunInput(t *testing.T) {
 				Status:       &[]githubv4.RequestableCheckStatusState{githubv4.RequestableCheckStatusStateCompleted}[0],
 				Conclusion:   &[]githubv4.CheckConclusionState{githubv4.CheckConclusionStateFailure}[0],
 				Output: &githubv4.CheckRunOutput{
-					Title:   githubv4.String("Two approvals are required (unsigned commits)"),
+					Title:   githubv4.String("More approvals are required (1 approval, 2 required: unsigned commits)"),
 					Summary: githubv4.String("Two approvals required"),
 				},
 			},
@@ -177,8 +185,10 @@ func TestController_newCheckRunInput(t *testing.T) {
 				HeadSHA: "abc123",
 			},
 			result: &validation.Result{
-				State:         validation.StateTwoApprovalsAreRequired,
-				SelfApprovers: map[string]struct{}{"committer": {}},
+				State:             validation.StateTwoApprovalsAreRequired,
+				SelfApprovers:     map[string][]string{"committer": {"committer"}},
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
 				UntrustedCommits: []*github.UntrustedCommit{
 					{
 						Login:           "committer",
@@ -194,11 +204,76 @@ func TestController_newCheckRunInput(t *testing.T) {
 				Status:       &[]githubv4.RequestableCheckStatusState{githubv4.RequestableCheckStatusStateCompleted}[0],
 				Conclusion:   &[]githubv4.CheckConclusionState{githubv4.CheckConclusionStateFailure}[0],
 				Output: &githubv4.CheckRunOutput{
-					Title:   githubv4.String("Two approvals are required (unsigned commits, self-approval)"),
+					Title:   githubv4.String("More approvals are required (1 approval, 2 required: unsigned commits, self-approval)"),
+					Summary: githubv4.String("Two approvals required"),
+				},
+			},
+		},
+		{
+			name: "more approvals required state - no reasons",
+			config: &config.Config{
+				CheckName:      "test-check",
+				BuiltTemplates: templates,
+			},
+			trust: &config.Trust{
+				TrustedApps: []string{"dependabot[bot]"},
+
+				UntrustedMachineUsers: []string{"untrusted-*"},
+			},
+			event: &Event{
+				RepoID:  "12345",
+				HeadSHA: "abc123",
+			},
+			result: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
+			},
+			expected: githubv4.CreateCheckRunInput{
+				RepositoryID: githubv4.String("12345"),
+				HeadSha:      githubv4.GitObjectID("abc123"),
+				Name:         githubv4.String("test-check"),
+				Status:       &[]githubv4.RequestableCheckStatusState{githubv4.RequestableCheckStatusStateCompleted}[0],
+				Conclusion:   &[]githubv4.CheckConclusionState{githubv4.CheckConclusionStateFailure}[0],
+				Output: &githubv4.CheckRunOutput{
+					Title:   githubv4.String("More approvals are required (1 approval, 2 required)"),
 					Summary: githubv4.String("Two approvals required"),
 				},
 			},
 		},
+		{
+			name: "changes requested state",
+			config: &config.Config{
+				CheckName:      "test-check",
+				BuiltTemplates: templates,
+			},
+			trust: &config.Trust{
+				TrustedApps:           []string{"dependabot[bot]"},
+				UntrustedMachineUsers: []string{"untrusted-*"},
+			},
+			event: &Event{
+				RepoID:  "12345",
+				HeadSHA: "abc123",
+			},
+			result: &validation.Result{
+				State:             validation.StateChangesRequested,
+				Approvers:         []string{"user1", "user2"},
+				ChangesRequesters: []string{"user3", "user4"},
+				ApprovalCount:     2,
+				RequiredApprovals: 1,
+			},
+			expected: githubv4.CreateCheckRunInput{
+				RepositoryID: githubv4.String("12345"),
+				HeadSha:      githubv4.GitObjectID("abc123"),
+				Name:         githubv4.String("test-check"),
+				Status:       &[]githubv4.RequestableCheckStatusState{githubv4.RequestableCheckStatusStateCompleted}[0],
+				Conclusion:   &[]githubv4.CheckConclusionState{githubv4.CheckConclusionStateFailure}[0],
+				Output: &githubv4.CheckRunOutput{
+					Title:   githubv4.String("Changes are requested by user3, user4"),
+					Summary: githubv4.String("Changes requested by [user3 user4]"),
+				},
+			},
+		},
 		{
 			name: "error state",
 			config: &config.Config{
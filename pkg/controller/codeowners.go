@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/codeowners"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+// getCodeOwners reads CODEOWNERS at the base commit of the pull request
+// and returns CODEOWNERS rules matching changed files and lines ignored because their patterns aren't supported.
+// CODEOWNERS is read from the base commit because the pull request can modify CODEOWNERS.
+func (c *Controller) getCodeOwners(ctx context.Context, logger *slog.Logger, ev *Event, pr *github.PullRequest, files []string, members *membership) ([]*validation.CodeOwnerRule, []*validation.IgnoredCodeOwnersLine, error) {
+	content, err := c.gh.GetCodeOwners(ctx, ev.RepoOwner, ev.RepoName, pr.BaseSHA)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get CODEOWNERS: %w", err)
+	}
+	if content == "" {
+		logger.Info("CODEOWNERS isn't found, so code owner approvals aren't required", "base_sha", pr.BaseSHA)
+		return []*validation.CodeOwnerRule{}, nil, nil
+	}
+	ruleset, err := codeowners.Parse(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse CODEOWNERS: %w", err)
+	}
+	var ignored []*validation.IgnoredCodeOwnersLine
+	for _, l := range ruleset.InvalidLines {
+		slogerr.WithError(logger, l.Err).Warn("ignore a line of CODEOWNERS", "base_sha", pr.BaseSHA, "line", l.Line)
+		ignored = append(ignored, &validation.IgnoredCodeOwnersLine{Line: l.Line, Pattern: l.Pattern})
+	}
+
+	rules := []*validation.CodeOwnerRule{}
+	ruleMap := map[*codeowners.Rule]*validation.CodeOwnerRule{}
+	for _, file := range files {
+		rule := ruleset.Match(file)
+		if rule == nil || len(rule.Owners) == 0 {
+			continue
+		}
+		if r, ok := ruleMap[rule]; ok {
+			r.Paths = append(r.Paths, file)
+			continue
+		}
+		r := &validation.CodeOwnerRule{
+			Owners: rule.Owners,
+			Paths:  []string{file},
+		}
+		ruleMap[rule] = r
+		rules = append(rules, r)
+	}
+
+	for _, rule := range rules {
+		logins, err := expandCodeOwners(ctx, logger, rule.Owners, members)
+		if err != nil {
+			return nil, nil, err
+		}
+		rule.Logins = logins
+	}
+	return rules, ignored, nil
+}
+
+// expandCodeOwners converts code owners to lower-cased logins.
+// Teams are expanded to their members.
+// Email addresses are ignored because they can't be mapped to GitHub users.
+func expandCodeOwners(ctx context.Context, logger *slog.Logger, owners []string, members *membership) (map[string]struct{}, error) {
+	logins := map[string]struct{}{}
+	for _, owner := range owners {
+		name, ok := strings.CutPrefix(owner, "@")
+		if !ok {
+			logger.Warn("ignore a code owner which isn't a GitHub user or team", "code_owner", owner)
+			continue
+		}
+		org, team, isTeam := strings.Cut(name, "/")
+		if !isTeam {
+			logins[strings.ToLower(name)] = struct{}{}
+			continue
+		}
+		teamMembers, err := members.teamMembers(ctx, org, team)
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(logins, teamMembers)
+	}
+	return logins, nil
+}
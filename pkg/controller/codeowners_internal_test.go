@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+func TestController_getCodeOwners(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	tests := []struct {
+		name        string
+		mock        *mockGitHub
+		files       []string
+		want        []*validation.CodeOwnerRule
+		wantIgnored []*validation.IgnoredCodeOwnersLine
+		wantErr     bool
+	}{
+		{
+			name:  "CODEOWNERS isn't found",
+			mock:  &mockGitHub{},
+			files: []string{"README.md"},
+			want:  []*validation.CodeOwnerRule{},
+		},
+		{
+			name: "teams are expanded and files are grouped by rules",
+			mock: &mockGitHub{
+				codeOwners: map[string]string{
+					"base-sha": `* @Octocat
+/terraform/ @org/infra email@example.com
+/docs/
+`,
+				},
+				teamMembers: map[string][]string{
+					"org/infra": {"Alice", "bob"},
+				},
+			},
+			files: []string{"README.md", "terraform/main.tf", "docs/index.md", "terraform/variables.tf"},
+			want: []*validation.CodeOwnerRule{
+				{
+					Owners: []string{"@Octocat"},
+					Paths:  []string{"README.md"},
+					Logins: map[string]struct{}{"octocat": {}},
+				},
+				{
+					Owners: []string{"@org/infra", "email@example.com"},
+					Paths:  []string{"terraform/main.tf", "terraform/variables.tf"},
+					Logins: map[string]struct{}{"alice": {}, "bob": {}},
+				},
+			},
+		},
+		{
+			name: "lines with unsupported patterns are ignored",
+			mock: &mockGitHub{
+				codeOwners: map[string]string{
+					"base-sha": `* @octocat
+!docs/ @org/docs
+/terraform/ @alice
+`,
+				},
+			},
+			files: []string{"README.md", "terraform/main.tf"},
+			want: []*validation.CodeOwnerRule{
+				{
+					Owners: []string{"@octocat"},
+					Paths:  []string{"README.md"},
+					Logins: map[string]struct{}{"octocat": {}},
+				},
+				{
+					Owners: []string{"@alice"},
+					Paths:  []string{"terraform/main.tf"},
+					Logins: map[string]struct{}{"alice": {}},
+				},
+			},
+			wantIgnored: []*validation.IgnoredCodeOwnersLine{
+				{Line: 2, Pattern: "!docs/"},
+			},
+		},
+		{
+			name: "failed to list team members",
+			mock: &mockGitHub{
+				codeOwners: map[string]string{
+					"base-sha": "* @org/unknown\n",
+				},
+			},
+			files:   []string{"README.md"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := &Controller{gh: tt.mock}
+			ev := &Event{RepoOwner: "org", RepoName: "repo", PRNumber: 1}
+			pr := &github.PullRequest{BaseSHA: "base-sha"}
+			got, ignored, err := c.getCodeOwners(context.Background(), discardLogger, ev, pr, tt.files, newMembership(tt.mock))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getCodeOwners() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("getCodeOwners() mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantIgnored, ignored); diff != "" {
+				t.Errorf("ignored lines mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_matchSensitiveFiles(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		patterns []string
+		files    []string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:  "no pattern",
+			files: []string{"README.md"},
+		},
+		{
+			name:     "matched files",
+			patterns: []string{".github/workflows/**", "/terraform/prod/", "CODEOWNERS"},
+			files: []string{
+				"README.md",
+				".github/workflows/test.yaml",
+				"terraform/prod/main.tf",
+				"terraform/dev/main.tf",
+				"docs/CODEOWNERS",
+			},
+			want: []string{".github/workflows/test.yaml", "terraform/prod/main.tf", "docs/CODEOWNERS"},
+		},
+		{
+			name:     "invalid pattern",
+			patterns: []string{"!foo"},
+			files:    []string{"README.md"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := matchSensitiveFiles(tt.patterns, tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matchSensitiveFiles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("matchSensitiveFiles() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
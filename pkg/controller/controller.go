@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sync/atomic"
 
 	"github.com/shurcooL/githubv4"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
@@ -12,7 +13,10 @@ import (
 )
 
 type Controller struct {
-	input             *InputNew
+	input *InputNew
+	// config is the active config, which may be replaced by SetConfig.
+	// Each request uses a snapshot of it.
+	config            *atomic.Pointer[config.Config]
 	gh                GitHub
 	validator         Validator
 	validateSignature func(signature string, payload, secretToken []byte) error
@@ -24,25 +28,50 @@ func New(input *InputNew) (*Controller, error) {
 		AppID:          input.Config.AppID,
 		InstallationID: input.Config.InstallationID,
 		KeyFile:        input.GitHubAppPrivateKey,
+		Token:          input.GitHubToken,
 		Logger:         input.Logger,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("create GitHub client: %w", err)
 	}
+	cfg := &atomic.Pointer[config.Config]{}
+	cfg.Store(input.Config)
 	return &Controller{
 		input:             input,
+		config:            cfg,
 		gh:                gh,
 		validator:         validation.New(&validation.InputNew{}),
 		validateSignature: github.ValidateSignature,
 	}, nil
 }
 
+// SetConfig replaces the active config.
+// Requests in flight keep using the previous config.
+// The config must be initialized, and app_id and installation_id must not be changed because the GitHub client isn't recreated.
+func (c *Controller) SetConfig(cfg *config.Config) {
+	c.config.Store(cfg)
+}
+
+// snapshot returns a copy of the controller using the active config during a request.
+func (c *Controller) snapshot() *Controller {
+	if c.config == nil {
+		return c
+	}
+	s := *c
+	input := *c.input
+	input.Config = c.config.Load()
+	s.input = &input
+	return &s
+}
+
 type InputNew struct {
 	Config              *config.Config
 	Version             string
 	WebhookSecret       []byte
 	GitHubAppPrivateKey string
-	Logger              *slog.Logger
+	// GitHubToken is an access token used instead of the GitHub App
+	GitHubToken string
+	Logger      *slog.Logger
 }
 
 type Validator interface {
@@ -54,6 +83,12 @@ type GitHub interface {
 	CreateCheckRun(ctx context.Context, input githubv4.CreateCheckRunInput) error
 	CompareCommits(ctx context.Context, owner, repo, base, head string) ([]string, error)
 	IsAncestor(ctx context.Context, owner, repo, ancestor, descendant string) (bool, error)
+	GetCodeOwners(ctx context.Context, owner, repo, ref string) (string, error)
+	ListPRFiles(ctx context.Context, owner, repo string, number int) ([]string, error)
+	ListTeamMembers(ctx context.Context, org, team string) ([]string, error)
+	IsOrgMember(ctx context.Context, org, user string) (bool, error)
+	GetRepo(ctx context.Context, owner, name string) (*github.Repository, error)
+	GetDefaultBranchFile(ctx context.Context, owner, repo, path string) (string, error)
 }
 
 type Request struct {
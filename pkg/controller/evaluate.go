@@ -0,0 +1,154 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+// EvaluateInput is a pull request to be evaluated.
+type EvaluateInput struct {
+	RepoOwner string
+	RepoName  string
+	PRNumber  int
+	// CarryForward evaluates the pull request as if new commits were pushed, carrying forward approvals of earlier commits.
+	CarryForward bool
+	// Apply creates the check run with the result.
+	Apply bool
+	// Record records the pull request and responses of GitHub API to Evaluation.Snapshot.
+	Record bool
+}
+
+// Evaluation is the result of Evaluate.
+// If the pull request isn't validated, Result is nil.
+type Evaluation struct {
+	Result     *validation.Result `json:"result"`
+	Conclusion string             `json:"conclusion,omitempty"`
+	Title      string             `json:"title,omitempty"`
+	Summary    string             `json:"summary,omitempty"`
+	// Applied is true if the check run is created
+	Applied bool `json:"applied"`
+	// Snapshot is set if EvaluateInput.Record is true
+	Snapshot *Snapshot `json:"-"`
+}
+
+// Evaluate validates the pull request in the same way as webhook events without receiving them.
+// The check run is created only if input.Apply is true.
+func (c *Controller) Evaluate(ctx context.Context, logger *slog.Logger, input *EvaluateInput) (*Evaluation, error) {
+	c = c.snapshot()
+	var rec *recorder
+	if input.Record {
+		rec = &recorder{GitHub: c.gh, snapshot: newSnapshot(input.RepoOwner, input.RepoName, input.PRNumber)}
+		c.gh = rec
+	}
+	ev, err := c.newEvaluateEvent(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if rec != nil {
+		if err := rec.prefetch(ctx, rec.snapshot.PullRequest); err != nil {
+			return nil, fmt.Errorf("record the snapshot: %w", err)
+		}
+	}
+	logger = logger.With(
+		"repository", ev.RepoFullName,
+		"pr_number", ev.PRNumber,
+		"sha", ev.HeadSHA,
+	)
+	result, policy, err := c.evaluate(ctx, logger, ev)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return &Evaluation{Snapshot: rec.getSnapshot()}, nil
+	}
+	checkRun := c.newCheckRunInput(logger, ev, result, policy.Trust, policy.Insecure)
+	evaluation := newEvaluation(result, checkRun)
+	evaluation.Snapshot = rec.getSnapshot()
+	if !input.Apply {
+		return evaluation, nil
+	}
+	if err := c.gh.CreateCheckRun(ctx, checkRun); err != nil {
+		return nil, fmt.Errorf("create a check run: %w", err)
+	}
+	evaluation.Applied = true
+	return evaluation, nil
+}
+
+// DryRun handles the webhook in the same way as Run, but returns the decision instead of creating the check run.
+// If the webhook is ignored or the pull request isn't validated, Result of the evaluation is nil.
+func (c *Controller) DryRun(ctx context.Context, logger *slog.Logger, req *Request) (*Evaluation, error) {
+	c = c.snapshot()
+	ev, logger := c.receive(logger, req)
+	if ev == nil {
+		return &Evaluation{}, nil
+	}
+	result, policy, err := c.evaluate(ctx, logger, ev)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return &Evaluation{}, nil
+	}
+	result.RequestID = req.RequestID
+	return newEvaluation(result, c.newCheckRunInput(logger, ev, result, policy.Trust, policy.Insecure)), nil
+}
+
+func newEvaluation(result *validation.Result, checkRun githubv4.CreateCheckRunInput) *Evaluation {
+	return &Evaluation{
+		Result:     result,
+		Conclusion: string(*checkRun.Conclusion),
+		Title:      string(checkRun.Output.Title),
+		Summary:    string(checkRun.Output.Summary),
+	}
+}
+
+// newEvaluateEvent returns the event equivalent to the webhook event of the pull request.
+func (c *Controller) newEvaluateEvent(ctx context.Context, input *EvaluateInput) (*Event, error) {
+	pr, err := c.gh.GetPR(ctx, input.RepoOwner, input.RepoName, input.PRNumber)
+	if err != nil {
+		return nil, fmt.Errorf("get a pull request: %w", err)
+	}
+	ev := &Event{
+		EventType:    eventPullRequestReview,
+		RepoFullName: input.RepoOwner + "/" + input.RepoName,
+		RepoOwner:    input.RepoOwner,
+		RepoName:     input.RepoName,
+		PRNumber:     input.PRNumber,
+		HeadSHA:      pr.HeadSHA,
+		BaseRef:      pr.BaseRef,
+		pr:           pr,
+	}
+	if input.CarryForward {
+		ev.EventType = eventPullRequest
+		ev.Action = "synchronize"
+	}
+	if input.Apply {
+		// The node ID of the repository is required to create the check run
+		repo, err := c.gh.GetRepo(ctx, input.RepoOwner, input.RepoName)
+		if err != nil {
+			return nil, fmt.Errorf("get the repository: %w", err)
+		}
+		ev.RepoID = repo.NodeID
+	}
+	return ev, nil
+}
+
+// getPR returns the pull request of the event.
+// The pull request fetched in advance is copied because validation modifies it and the event may be evaluated more than once.
+func (c *Controller) getPR(ctx context.Context, ev *Event) (*github.PullRequest, error) {
+	if ev.pr == nil {
+		return c.gh.GetPR(ctx, ev.RepoOwner, ev.RepoName, ev.PRNumber) //nolint:wrapcheck
+	}
+	pr := *ev.pr
+	pr.Commits = make([]*github.Commit, len(ev.pr.Commits))
+	for i, commit := range ev.pr.Commits {
+		c := *commit
+		pr.Commits[i] = &c
+	}
+	return &pr, nil
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+func TestController_Evaluate(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	tests := []struct {
+		name        string
+		input       *EvaluateInput
+		pr          *github.PullRequest
+		wantState   validation.State
+		wantSkipped bool
+	}{
+		{
+			name:  "approved",
+			input: &EvaluateInput{RepoOwner: "org", RepoName: "repo", PRNumber: 1},
+			pr: &github.PullRequest{
+				HeadSHA:   "head",
+				BaseRef:   "main",
+				Approvers: map[string]*github.User{"carol": {Login: "carol"}},
+				Commits: []*github.Commit{
+					{SHA: "head", Committer: &github.User{Login: "alice"}, Signature: &github.Signature{IsValid: true, State: "VALID"}},
+				},
+			},
+			wantState: validation.StateApproved,
+		},
+		{
+			name:  "apply",
+			input: &EvaluateInput{RepoOwner: "org", RepoName: "repo", PRNumber: 1, Apply: true},
+			pr: &github.PullRequest{
+				HeadSHA:   "head",
+				BaseRef:   "main",
+				Approvers: map[string]*github.User{},
+			},
+			wantState: validation.StateApprovalIsRequired,
+		},
+		{
+			name:  "carry forward isn't applicable",
+			input: &EvaluateInput{RepoOwner: "org", RepoName: "repo", PRNumber: 1, CarryForward: true},
+			pr: &github.PullRequest{
+				HeadSHA:   "head",
+				BaseRef:   "main",
+				Approvers: map[string]*github.User{},
+				Commits: []*github.Commit{
+					{SHA: "head", Committer: &github.User{Login: "alice"}},
+				},
+			},
+			wantSkipped: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &config.Config{}
+			if err := config.Parse(cfg, []byte("app_id: 1\ninstallation_id: 2\n")); err != nil {
+				t.Fatal(err)
+			}
+			gh := &mockGitHub{pr: tt.pr}
+			c := &Controller{
+				input:     &InputNew{Config: cfg},
+				gh:        gh,
+				validator: validation.New(&validation.InputNew{}),
+			}
+			got, err := c.Evaluate(t.Context(), discardLogger, tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gh.getPRCalls != 1 {
+				t.Errorf("the pull request must be fetched once: %d", gh.getPRCalls)
+			}
+			if tt.wantSkipped {
+				if got.Result != nil {
+					t.Fatalf("the pull request must not be validated: %+v", got.Result)
+				}
+				return
+			}
+			if got.Result == nil {
+				t.Fatal("the pull request must be validated")
+			}
+			if got.Result.State != tt.wantState {
+				t.Errorf("State = %s, want %s", got.Result.State, tt.wantState)
+			}
+			if got.Summary == "" {
+				t.Error("the summary must be rendered")
+			}
+			if got.Applied != tt.input.Apply {
+				t.Errorf("Applied = %v, want %v", got.Applied, tt.input.Apply)
+			}
+			if !tt.input.Apply {
+				if len(gh.checkRuns) != 0 {
+					t.Fatal("a check run must not be created without Apply")
+				}
+				return
+			}
+			if len(gh.checkRuns) != 1 {
+				t.Fatalf("a check run must be created: %d", len(gh.checkRuns))
+			}
+			if gh.checkRuns[0].RepositoryID != githubv4.String("R_repo") {
+				t.Errorf("RepositoryID = %v, want R_repo", gh.checkRuns[0].RepositoryID)
+			}
+		})
+	}
+}
@@ -5,8 +5,12 @@ import (
 )
 
 func ignore(logger *slog.Logger, ev *Event) bool {
-	// For pull_request events, only process "synchronize" action.
+	// For pull_request events, only process "synchronize" action and changes of the base branch.
+	// Branch rules may differ between base branches, so the pull request is re-validated if the base branch is changed.
 	if ev.EventType == eventPullRequest {
+		if ev.Action == "edited" && ev.BaseChanged {
+			return false
+		}
 		if ev.Action != "synchronize" {
 			logger.Debug("ignore the pull_request event because the action is not 'synchronize'", "action", ev.Action)
 			return true
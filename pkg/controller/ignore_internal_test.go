@@ -22,6 +22,31 @@ func Test_ignore(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name: "do not ignore pull_request synchronize action",
+			event: &Event{
+				EventType: eventPullRequest,
+				Action:    "synchronize",
+			},
+			expected: false,
+		},
+		{
+			name: "do not ignore pull_request edited action changing the base branch",
+			event: &Event{
+				EventType:   eventPullRequest,
+				Action:      "edited",
+				BaseChanged: true,
+			},
+			expected: false,
+		},
+		{
+			name: "ignore pull_request edited action not changing the base branch",
+			event: &Event{
+				EventType: eventPullRequest,
+				Action:    "edited",
+			},
+			expected: true,
+		},
 		{
 			name: "ignore commented state",
 			event: &Event{
@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+// membership looks up team and organization memberships.
+// Results are cached, so a membership is created per request.
+type membership struct {
+	gh    GitHub
+	teams map[string]map[string]struct{}
+	orgs  map[string]bool
+}
+
+func newMembership(gh GitHub) *membership {
+	return &membership{
+		gh:    gh,
+		teams: map[string]map[string]struct{}{},
+		orgs:  map[string]bool{},
+	}
+}
+
+// teamMembers returns lower-cased logins of members of the team.
+func (m *membership) teamMembers(ctx context.Context, org, team string) (map[string]struct{}, error) {
+	key := strings.ToLower(org + "/" + team)
+	if members, ok := m.teams[key]; ok {
+		return members, nil
+	}
+	logins, err := m.gh.ListTeamMembers(ctx, org, team)
+	if err != nil {
+		return nil, fmt.Errorf("list members of the team %s/%s: %w", org, team, err)
+	}
+	members := make(map[string]struct{}, len(logins))
+	for _, login := range logins {
+		members[strings.ToLower(login)] = struct{}{}
+	}
+	m.teams[key] = members
+	return members, nil
+}
+
+// isOrgMember reports whether the user is a member of the organization.
+func (m *membership) isOrgMember(ctx context.Context, org, login string) (bool, error) {
+	key := strings.ToLower(org + "/" + login)
+	if isMember, ok := m.orgs[key]; ok {
+		return isMember, nil
+	}
+	isMember, err := m.gh.IsOrgMember(ctx, org, login)
+	if err != nil {
+		return false, fmt.Errorf("check if %s is a member of the organization %s: %w", login, org, err)
+	}
+	m.orgs[key] = isMember
+	return isMember, nil
+}
+
+// isApprover reports whether the user belongs to any of approver teams and approver organizations.
+func (m *membership) isApprover(ctx context.Context, trust *config.Trust, login string) (bool, error) {
+	for _, team := range trust.ApproverTeams {
+		org, slug, _ := strings.Cut(team, "/")
+		members, err := m.teamMembers(ctx, org, slug)
+		if err != nil {
+			return false, err
+		}
+		if _, ok := members[strings.ToLower(login)]; ok {
+			return true, nil
+		}
+	}
+	for _, org := range trust.ApproverOrgs {
+		isMember, err := m.isOrgMember(ctx, org, login)
+		if err != nil {
+			return false, err
+		}
+		if isMember {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getApproverMembers returns lower-cased logins of reviewers belonging to approver teams or approver organizations.
+// Reviewers are approvers and users requesting changes.
+// Reviews from GitHub Apps are verified by trusted apps, so apps aren't looked up.
+func (m *membership) getApproverMembers(ctx context.Context, trust *config.Trust, pr *github.PullRequest) (map[string]struct{}, error) {
+	members := map[string]struct{}{}
+	for login, user := range joinReviewers(pr.Approvers, pr.ChangesRequesters) {
+		if user.IsApp {
+			continue
+		}
+		ok, err := m.isApprover(ctx, trust, login)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			members[strings.ToLower(login)] = struct{}{}
+		}
+	}
+	return members, nil
+}
+
+// resolveTeams returns a copy of the matcher resolving team references to their members.
+func (m *membership) resolveTeams(ctx context.Context, matcher *config.Matcher) (*config.Matcher, error) {
+	teams := matcher.Teams()
+	if len(teams) == 0 {
+		return matcher, nil
+	}
+	members := make(map[string]map[string]struct{}, len(teams))
+	for _, team := range teams {
+		org, slug, _ := strings.Cut(team, "/")
+		logins, err := m.teamMembers(ctx, org, slug)
+		if err != nil {
+			return nil, err
+		}
+		members[team] = logins
+	}
+	return matcher.WithTeamMembers(members), nil
+}
+
+func joinReviewers(reviewers ...map[string]*github.User) map[string]*github.User {
+	m := map[string]*github.User{}
+	for _, r := range reviewers {
+		maps.Copy(m, r)
+	}
+	return m
+}
@@ -0,0 +1,118 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+func Test_membership_getApproverMembers(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	tests := []struct {
+		name      string
+		trust     *config.Trust
+		mock      *mockGitHub
+		approvers map[string]*github.User
+		want      map[string]struct{}
+		wantErr   bool
+	}{
+		{
+			name: "team and organization members",
+			trust: &config.Trust{
+				ApproverTeams: []string{"org/reviewers", "org/admins"},
+				ApproverOrgs:  []string{"org"},
+			},
+			mock: &mockGitHub{
+				teamMembers: map[string][]string{
+					"org/reviewers": {"Alice"},
+					"org/admins":    {"bob"},
+				},
+				orgMembers: map[string][]string{
+					"org": {"carol"},
+				},
+			},
+			approvers: map[string]*github.User{
+				"alice":    {Login: "alice"},
+				"bob":      {Login: "bob"},
+				"carol":    {Login: "carol"},
+				"outsider": {Login: "outsider"},
+				"app":      {Login: "app", IsApp: true},
+			},
+			want: map[string]struct{}{"alice": {}, "bob": {}, "carol": {}},
+		},
+		{
+			name: "failed to list team members",
+			trust: &config.Trust{
+				ApproverTeams: []string{"org/unknown"},
+			},
+			mock: &mockGitHub{},
+			approvers: map[string]*github.User{
+				"alice": {Login: "alice"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			m := newMembership(tt.mock)
+			got, err := m.getApproverMembers(context.Background(), tt.trust, &github.PullRequest{Approvers: tt.approvers})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getApproverMembers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("getApproverMembers() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_membership_cache(t *testing.T) {
+	t.Parallel()
+	mock := &mockGitHub{
+		teamMembers: map[string][]string{"org/reviewers": {"alice"}},
+		orgMembers:  map[string][]string{"org": {"bob"}},
+	}
+	m := newMembership(mock)
+	ctx := context.Background()
+	for range 2 {
+		if _, err := m.teamMembers(ctx, "org", "reviewers"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := m.isOrgMember(ctx, "org", "bob"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if mock.listTeamMembersCalls != 1 {
+		t.Errorf("ListTeamMembers is called %d times, want 1", mock.listTeamMembersCalls)
+	}
+	if mock.isOrgMemberCalls != 1 {
+		t.Errorf("IsOrgMember is called %d times, want 1", mock.isOrgMemberCalls)
+	}
+}
+
+func Test_membership_resolveTeams(t *testing.T) {
+	t.Parallel()
+	mock := &mockGitHub{
+		teamMembers: map[string][]string{"org/bots": {"CI-Bot"}},
+	}
+	matcher, err := config.NewUserMatcher([]string{"@org/bots", "deploy-bot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resolved, err := newMembership(mock).resolveTeams(context.Background(), matcher)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for login, want := range map[string]bool{"ci-bot": true, "deploy-bot": true, "octocat": false} {
+		if got := resolved.Match(login); got != want {
+			t.Errorf("Match(%q) = %v, want %v", login, got, want)
+		}
+	}
+	if matcher.Match("ci-bot") {
+		t.Error("the original matcher shouldn't be modified")
+	}
+}
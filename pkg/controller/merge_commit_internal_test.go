@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -14,17 +15,31 @@ import (
 var discardLogger = slog.New(slog.DiscardHandler) //nolint:gochecknoglobals
 
 type mockGitHub struct {
+	pr             *github.PullRequest
 	compareResult  map[string][]string // key: "base...head"
 	compareErr     map[string]error    // key: "base...head"
 	ancestorResult map[string]bool     // key: "ancestor...descendant"
 	ancestorErr    map[string]error    // key: "ancestor...descendant"
+	codeOwners     map[string]string   // key: ref
+	prFiles        []string
+	files          map[string]string   // key: path on the default branch
+	teamMembers    map[string][]string // key: "org/team"
+	orgMembers     map[string][]string // key: org
+	// created check runs
+	checkRuns []githubv4.CreateCheckRunInput
+	// the number of API calls to check if the cache works
+	listTeamMembersCalls int
+	isOrgMemberCalls     int
+	getPRCalls           int
 }
 
 func (m *mockGitHub) GetPR(_ context.Context, _, _ string, _ int) (*github.PullRequest, error) {
-	return nil, nil //nolint:nilnil
+	m.getPRCalls++
+	return m.pr, nil
 }
 
-func (m *mockGitHub) CreateCheckRun(_ context.Context, _ githubv4.CreateCheckRunInput) error {
+func (m *mockGitHub) CreateCheckRun(_ context.Context, input githubv4.CreateCheckRunInput) error {
+	m.checkRuns = append(m.checkRuns, input)
 	return nil
 }
 
@@ -50,6 +65,40 @@ func (m *mockGitHub) IsAncestor(_ context.Context, _, _, ancestor, descendant st
 	return false, nil
 }
 
+func (m *mockGitHub) GetCodeOwners(_ context.Context, _, _, ref string) (string, error) {
+	return m.codeOwners[ref], nil
+}
+
+func (m *mockGitHub) ListPRFiles(_ context.Context, _, _ string, _ int) ([]string, error) {
+	return m.prFiles, nil
+}
+
+func (m *mockGitHub) ListTeamMembers(_ context.Context, org, team string) ([]string, error) {
+	m.listTeamMembersCalls++
+	members, ok := m.teamMembers[org+"/"+team]
+	if !ok {
+		return nil, errors.New("team not found")
+	}
+	return members, nil
+}
+
+func (m *mockGitHub) IsOrgMember(_ context.Context, org, user string) (bool, error) {
+	m.isOrgMemberCalls++
+	members, ok := m.orgMembers[org]
+	if !ok {
+		return false, errors.New("organization not found")
+	}
+	return slices.Contains(members, user), nil
+}
+
+func (m *mockGitHub) GetRepo(_ context.Context, owner, name string) (*github.Repository, error) {
+	return &github.Repository{FullName: owner + "/" + name, NodeID: "R_" + name}, nil
+}
+
+func (m *mockGitHub) GetDefaultBranchFile(_ context.Context, _, _, path string) (string, error) {
+	return m.files[path], nil
+}
+
 func Test_isCleanMergeCommit(t *testing.T) { //nolint:funlen
 	t.Parallel()
 	defaultPRCommitSHAs := map[string]struct{}{
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+)
+
+// applyRepoFile overrides the repository config with the repository config file if the config allows it.
+// The file is read from the default branch, never the head of the pull request.
+// If the file is invalid, it returns the repository config as is and the problem of the file,
+// which is reported as a failing check.
+func (c *Controller) applyRepoFile(ctx context.Context, logger *slog.Logger, ev *Event, repo *config.Repository) (*config.Repository, string, error) {
+	if repo == nil || repo.RepoFile == nil {
+		return repo, "", nil
+	}
+	content, err := c.gh.GetDefaultBranchFile(ctx, ev.RepoOwner, ev.RepoName, config.RepoFilePath)
+	if err != nil {
+		return nil, "", fmt.Errorf("get the repository config file: %w", err)
+	}
+	if content == "" {
+		return repo, "", nil
+	}
+	f, err := config.ParseRepoFile([]byte(content))
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("the repository config file is invalid")
+		return repo, err.Error(), nil
+	}
+	r, err := repo.WithRepoFile(f)
+	if err != nil {
+		slogerr.WithError(logger, err).Warn("the repository config file violates the repo_file policy")
+		return repo, err.Error(), nil
+	}
+	return r, "", nil
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+func TestController_applyRepoFile(t *testing.T) {
+	t.Parallel()
+	cfg := &config.Config{
+		RequiredApprovals:                     2,
+		RequiredApprovalsWithUntrustedCommits: 3,
+		RepoFile: &config.RepoFilePolicy{
+			Stricter: []string{"required_approvals"},
+		},
+	}
+	if err := cfg.Init(); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := cfg.GetEffectiveRepo(&github.Repository{FullName: "org/repo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name         string
+		file         string
+		wantRequired int
+		wantErr      bool
+	}{
+		{
+			name:         "no file",
+			wantRequired: 2,
+		},
+		{
+			name:         "stricter",
+			file:         "required_approvals: 3",
+			wantRequired: 3,
+		},
+		{
+			name:         "invalid file",
+			file:         "required_approvals: 1",
+			wantRequired: 2,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := &Controller{gh: &mockGitHub{files: map[string]string{config.RepoFilePath: tt.file}}}
+			ev := &Event{RepoOwner: "org", RepoName: "repo"}
+			got, repoFileErr, err := c.applyRepoFile(context.Background(), discardLogger, ev, repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (repoFileErr != "") != tt.wantErr {
+				t.Errorf("repoFileErr = %q, wantErr %v", repoFileErr, tt.wantErr)
+			}
+			if got.RequiredApprovals != tt.wantRequired {
+				t.Errorf("RequiredApprovals = %d, want %d", got.RequiredApprovals, tt.wantRequired)
+			}
+		})
+	}
+}
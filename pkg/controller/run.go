@@ -7,15 +7,39 @@ import (
 
 	"github.com/suzuki-shunsuke/slog-error/slogerr"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
 )
 
 func (c *Controller) Run(ctx context.Context, logger *slog.Logger, req *Request) error {
 	logger.Debug("Starting a request", "request", req)
+	c = c.snapshot()
+	ev, logger := c.receive(logger, req)
+	if ev == nil {
+		return nil
+	}
+	result, policy, err := c.evaluate(ctx, logger, ev)
+	if err != nil {
+		return err
+	}
+	if result == nil {
+		return nil
+	}
+	result.RequestID = req.RequestID
+
+	if err := c.gh.CreateCheckRun(ctx, c.newCheckRunInput(logger, ev, result, policy.Trust, policy.Insecure)); err != nil {
+		slogerr.WithError(logger, err).Error("create final check run")
+	}
+	return nil
+}
+
+// receive verifies the webhook and returns its event and the logger with attributes of the event.
+// If the webhook is ignored, the event is nil.
+func (c *Controller) receive(logger *slog.Logger, req *Request) (*Event, *slog.Logger) {
 	// Validate the request
 	ev := c.verifyWebhook(logger, req)
 	if ev == nil {
-		return nil
+		return nil, logger
 	}
 	logger = logger.With(
 		"repository", ev.RepoFullName,
@@ -23,83 +47,70 @@ func (c *Controller) Run(ctx context.Context, logger *slog.Logger, req *Request)
 		"sha", ev.HeadSHA,
 		"pr_url", fmt.Sprintf("https://github.com/%s/pull/%d", ev.RepoFullName, ev.PRNumber),
 	)
-
 	if ignore(logger, ev) {
-		return nil
+		return nil, logger
+	}
+	return ev, logger
+}
+
+// evaluate validates the pull request of the event with the effective policy of the repository.
+// It returns nil if the pull request isn't validated, for example because the repository is ignored or the event is stale.
+func (c *Controller) evaluate(ctx context.Context, logger *slog.Logger, ev *Event) (*validation.Result, *config.Policy, error) {
+	repo, err := c.getRepo(ctx, ev)
+	if err != nil {
+		return nil, nil, err
 	}
-	repo := c.input.Config.GetRepo(ev.RepoFullName)
-	if repo != nil && repo.Ignored {
+	if repo != nil && repo.IsIgnored() {
 		logger.Info("ignore the event because the repository is ignored in the config", "repository", ev.RepoFullName)
-		return nil
+		return nil, nil, nil
+	}
+	repo, repoFileErr, err := c.applyRepoFile(ctx, logger, ev, repo)
+	if err != nil {
+		return nil, nil, err
 	}
-	var repoTrust *config.Trust
-	var repoInsecure *config.Insecure
-	if repo != nil {
-		repoTrust = repo.Trust
-		repoInsecure = repo.Insecure
+	policy, err := config.NewPolicy(c.input.Config, repo, ev.BaseRef)
+	if err != nil {
+		return nil, nil, err
 	}
-	trust := mergeTrust(c.input.Config.Trust, repoTrust)
-	insecure := mergeInsecure(c.input.Config.Insecure, repoInsecure)
-	trust.Init()
 
 	// Run validation
 	var result *validation.Result
-	if ev.EventType == eventPullRequest {
-		result = c.carryForwardCheck(ctx, logger, ev, &trust, &insecure)
+	switch {
+	case repoFileErr != "":
+		result = &validation.Result{
+			State:           validation.StateInvalidRepoConfig,
+			RepoConfigError: repoFileErr,
+		}
+	case ev.EventType == eventPullRequest && ev.Action == "synchronize":
+		result = c.carryForwardCheck(ctx, logger, ev, policy)
 		if result == nil {
 			logger.Info("carry-forward check not applicable, skipping")
-			return nil
+			return nil, nil, nil
 		}
-	} else {
-		result = c.validate(ctx, logger, ev, &trust, &insecure)
-	}
-	result.RequestID = req.RequestID
-
-	if err := c.gh.CreateCheckRun(ctx, c.newCheckRunInput(logger, ev, result, &trust, &insecure)); err != nil {
-		slogerr.WithError(logger, err).Error("create final check run")
-	}
-	return nil
-}
-
-func mergeTrust(global *config.Trust, repo *config.Trust) config.Trust {
-	var trust config.Trust
-	if global != nil {
-		trust = *global
-	}
-	if repo != nil {
-		if repo.TrustedApps != nil {
-			trust.TrustedApps = repo.TrustedApps
-		}
-		if repo.UntrustedMachineUsers != nil {
-			trust.UntrustedMachineUsers = repo.UntrustedMachineUsers
+	default:
+		result = c.validate(ctx, logger, ev, policy)
+		if result == nil {
+			return nil, nil, nil
 		}
 	}
-	return trust
+	return result, policy, nil
 }
 
-func mergeInsecure(global *config.Insecure, repo *config.Insecure) config.Insecure {
-	var insecure config.Insecure
-	if global != nil {
-		insecure = *global
-	}
-	if repo == nil {
-		return insecure
-	}
-	if repo.UnsignedCommitApps != nil {
-		insecure.UnsignedCommitApps = repo.UnsignedCommitApps
-		insecure.AllowUnsignedCommits = new(false)
-	}
-	if repo.UnsignedCommitMachineUsers != nil {
-		insecure.UnsignedCommitMachineUsers = repo.UnsignedCommitMachineUsers
-		insecure.AllowUnsignedCommits = new(false)
-	}
-	if repo.AllowUnsignedCommits != nil {
-		insecure.AllowUnsignedCommits = repo.AllowUnsignedCommits
-		if *repo.AllowUnsignedCommits {
-			// If repo.AllowUnsignedCommits is true, it overrides global UnsignedCommitApps and UnsignedCommitMachineUsers.
-			insecure.UnsignedCommitApps = nil
-			insecure.UnsignedCommitMachineUsers = nil
+// getRepo returns the repository config of the event.
+// If no repository config matches the repository, it returns a repository config equivalent to the root config.
+// Metadata of the repository is fetched only if repository configs select repositories by metadata.
+func (c *Controller) getRepo(ctx context.Context, ev *Event) (*config.Repository, error) {
+	meta := &github.Repository{FullName: ev.RepoFullName}
+	if c.input.Config.UsesRepoSelectors() {
+		m, err := c.gh.GetRepo(ctx, ev.RepoOwner, ev.RepoName)
+		if err != nil {
+			return nil, fmt.Errorf("get metadata of the repository: %w", err)
 		}
+		meta = m
+	}
+	repo, err := c.input.Config.GetEffectiveRepo(meta)
+	if err != nil {
+		return nil, fmt.Errorf("get the repository config: %w", err)
 	}
-	return insecure
+	return repo, nil
 }
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+// Outcomes of pull requests which aren't decided by validation.State.
+const (
+	OutcomeSkipped = "skipped"
+	OutcomeError   = "error"
+)
+
+// SimulateInput is a pull request to be validated with the current config and a candidate config.
+type SimulateInput struct {
+	RepoOwner string
+	RepoName  string
+	PRNumber  int
+	// Record records the pull request and responses of GitHub API to Simulation.Snapshot.
+	Record bool
+}
+
+// Simulation is decisions on the pull request with the current config and the candidate config.
+// A nil result means the pull request isn't validated, for example because the repository is ignored.
+type Simulation struct {
+	RepoFullName string             `json:"repository"`
+	PRNumber     int                `json:"pr_number"`
+	Current      *validation.Result `json:"current"`
+	Candidate    *validation.Result `json:"candidate"`
+	// Snapshot is set if SimulateInput.Record is true
+	Snapshot *Snapshot `json:"-"`
+}
+
+// Simulate validates the pull request with the current config and the candidate config.
+// Both configs see the same pull request, so only changes of the config change the decision.
+// Check runs aren't created.
+func (c *Controller) Simulate(ctx context.Context, logger *slog.Logger, candidate *config.Config, input *SimulateInput) (*Simulation, error) {
+	c = c.snapshot()
+	c = c.withConfig(c.input.Config)
+	var rec *recorder
+	if input.Record {
+		rec = &recorder{GitHub: c.gh, snapshot: newSnapshot(input.RepoOwner, input.RepoName, input.PRNumber)}
+		c.gh = rec
+	}
+	ev, err := c.newEvaluateEvent(ctx, &EvaluateInput{
+		RepoOwner: input.RepoOwner,
+		RepoName:  input.RepoName,
+		PRNumber:  input.PRNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rec != nil {
+		if err := rec.prefetch(ctx, rec.snapshot.PullRequest); err != nil {
+			return nil, fmt.Errorf("record the snapshot: %w", err)
+		}
+	}
+	logger = logger.With(
+		"repository", ev.RepoFullName,
+		"pr_number", ev.PRNumber,
+		"sha", ev.HeadSHA,
+	)
+	current, _, err := c.evaluate(ctx, logger, ev)
+	if err != nil {
+		return nil, fmt.Errorf("validate the pull request with the current config: %w", err)
+	}
+	next, _, err := c.withConfig(candidate).evaluate(ctx, logger, ev)
+	if err != nil {
+		return nil, fmt.Errorf("validate the pull request with the candidate config: %w", err)
+	}
+	return &Simulation{
+		RepoFullName: ev.RepoFullName,
+		PRNumber:     ev.PRNumber,
+		Current:      current,
+		Candidate:    next,
+		Snapshot:     rec.getSnapshot(),
+	}, nil
+}
+
+// withConfig returns a copy of the controller using the config.
+func (c *Controller) withConfig(cfg *config.Config) *Controller {
+	s := *c
+	input := *c.input
+	input.Config = cfg
+	s.input = &input
+	s.config = nil
+	return &s
+}
+
+// Changed reports whether the outcome of the pull request is changed by the candidate config.
+func (s *Simulation) Changed() bool {
+	return Outcome(s.Current) != Outcome(s.Candidate)
+}
+
+// Reasons returns reasons why the outcome is changed.
+// They're reasons of the candidate decision which the current decision doesn't have,
+// or else reasons of the current decision which the candidate decision doesn't have.
+// If the candidate decision is an error, the error is the reason.
+func (s *Simulation) Reasons() []string {
+	if s.Candidate != nil && s.Candidate.Error != "" {
+		return []string{s.Candidate.Error}
+	}
+	current, candidate := resultReasons(s.Current), resultReasons(s.Candidate)
+	if reasons := subtract(candidate, current); reasons != nil {
+		return reasons
+	}
+	return subtract(current, candidate)
+}
+
+// Outcome returns the state of the result, or OutcomeSkipped or OutcomeError.
+func Outcome(result *validation.Result) string {
+	switch {
+	case result == nil:
+		return OutcomeSkipped
+	case result.Error != "":
+		return OutcomeError
+	default:
+		return string(result.State)
+	}
+}
+
+func resultReasons(result *validation.Result) []string {
+	if result == nil {
+		return nil
+	}
+	return result.Reasons()
+}
+
+// subtract returns elements of a which b doesn't have.
+func subtract(a, b []string) []string {
+	var s []string
+	for _, v := range a {
+		if !slices.Contains(b, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
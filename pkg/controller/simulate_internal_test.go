@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+func TestController_Simulate(t *testing.T) {
+	t.Parallel()
+	newConfig := func(t *testing.T, s string) *config.Config {
+		t.Helper()
+		cfg := &config.Config{}
+		if err := config.Parse(cfg, []byte("app_id: 1\ninstallation_id: 2\n"+s)); err != nil {
+			t.Fatal(err)
+		}
+		return cfg
+	}
+	newPR := func() *github.PullRequest {
+		return &github.PullRequest{
+			HeadSHA:   "head",
+			BaseSHA:   "base",
+			BaseRef:   "main",
+			Approvers: map[string]*github.User{"carol": {Login: "carol"}},
+			Commits: []*github.Commit{
+				{SHA: "head", Committer: &github.User{Login: "deploy-bot"}, Signature: &github.Signature{IsValid: true, State: "VALID"}},
+			},
+		}
+	}
+	current := newConfig(t, "")
+	candidate := newConfig(t, "trust:\n  untrusted_machine_users: ['@org/bots']\n")
+	input := &SimulateInput{RepoOwner: "org", RepoName: "repo", PRNumber: 1, Record: true}
+	c := &Controller{
+		input: &InputNew{Config: current},
+		gh: &mockGitHub{
+			pr:          newPR(),
+			teamMembers: map[string][]string{"org/bots": {"deploy-bot"}},
+		},
+		validator: validation.New(&validation.InputNew{}),
+	}
+	simulation, err := c.Simulate(t.Context(), discardLogger, candidate, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !simulation.Changed() {
+		t.Fatalf("the outcome must be changed: %s -> %s", Outcome(simulation.Current), Outcome(simulation.Candidate))
+	}
+	if diff := cmp.Diff([]string{"untrusted machine user commits"}, simulation.Reasons()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// The snapshot has responses used by both configs, so the simulation can be repeated offline
+	b, err := json.Marshal(simulation.Snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		t.Fatal(err)
+	}
+	input.Record = false
+	replayed, err := NewFromSnapshot(&InputNew{Config: current}, snapshot).Simulate(t.Context(), discardLogger, candidate, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := Outcome(replayed.Current); got != string(validation.StateApproved) {
+		t.Errorf("current outcome = %s, want approved", got)
+	}
+	if got := Outcome(replayed.Candidate); got != string(validation.StateTwoApprovalsAreRequired) {
+		t.Errorf("candidate outcome = %s, want %s", got, validation.StateTwoApprovalsAreRequired)
+	}
+}
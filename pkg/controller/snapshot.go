@@ -0,0 +1,302 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/shurcooL/githubv4"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+var (
+	// errNotRecorded is returned if the snapshot doesn't have the response of GitHub API.
+	errNotRecorded = errors.New("the response isn't recorded in the snapshot")
+	// errMembershipNotRecorded is returned if the config refers to teams or organizations which the config used for recording doesn't refer to.
+	errMembershipNotRecorded = errors.New("the snapshot lacks team and organization data which the config refers to. Record the snapshot again with the config")
+)
+
+// Snapshot is a pull request and responses of GitHub API used to validate it.
+// The pull request can be validated again with the snapshot and another config without network.
+// Team members and organization membership are recorded only if the config used for recording refers to them,
+// because teams and organizations which other configs refer to can't be known in advance.
+// So configs referring to other teams or organizations fail with errMembershipNotRecorded.
+type Snapshot struct {
+	RepoOwner   string              `json:"repo_owner"`
+	RepoName    string              `json:"repo_name"`
+	PRNumber    int                 `json:"pr_number"`
+	PullRequest *github.PullRequest `json:"pull_request"`
+	Repository  *github.Repository  `json:"repository,omitempty"`
+	// PRFiles are files changed by the pull request
+	PRFiles []string `json:"pr_files"`
+	// Comparisons are files changed between commits. The key is <base>...<head>
+	Comparisons map[string][]string `json:"comparisons,omitempty"`
+	// Ancestors are whether a commit is an ancestor of another commit. The key is <ancestor>...<descendant>
+	Ancestors map[string]bool `json:"ancestors,omitempty"`
+	// CodeOwners are contents of CODEOWNERS per ref. An empty string means CODEOWNERS isn't found
+	CodeOwners map[string]string `json:"code_owners,omitempty"`
+	// DefaultBranchFiles are contents of files on the default branch per path. An empty string means the file isn't found
+	DefaultBranchFiles map[string]string `json:"default_branch_files,omitempty"`
+	// TeamMembers are members per team. The key is <org>/<team>
+	TeamMembers map[string][]string `json:"team_members,omitempty"`
+	// OrgMembers are whether a user is a member of an organization. The key is <org>/<user>
+	OrgMembers map[string]bool `json:"org_members,omitempty"`
+}
+
+func newSnapshot(owner, name string, number int) *Snapshot {
+	return &Snapshot{
+		RepoOwner:          owner,
+		RepoName:           name,
+		PRNumber:           number,
+		Comparisons:        map[string][]string{},
+		Ancestors:          map[string]bool{},
+		CodeOwners:         map[string]string{},
+		DefaultBranchFiles: map[string]string{},
+		TeamMembers:        map[string][]string{},
+		OrgMembers:         map[string]bool{},
+	}
+}
+
+// NewFromSnapshot returns a controller which reads the pull request and responses of GitHub API from the snapshot.
+// It doesn't access GitHub, so it can't create check runs.
+func NewFromSnapshot(input *InputNew, snapshot *Snapshot) *Controller {
+	return &Controller{
+		input:     input,
+		gh:        &snapshotGitHub{snapshot: snapshot},
+		validator: validation.New(&validation.InputNew{}),
+	}
+}
+
+// recorder records responses of GitHub API to the snapshot.
+type recorder struct {
+	GitHub
+	mu       sync.Mutex
+	snapshot *Snapshot
+}
+
+// getSnapshot returns the recorded snapshot. If r is nil, it returns nil.
+func (r *recorder) getSnapshot() *Snapshot {
+	if r == nil {
+		return nil
+	}
+	return r.snapshot
+}
+
+// prefetch records responses which only some configs use, so the snapshot can be evaluated with other configs.
+func (r *recorder) prefetch(ctx context.Context, pr *github.PullRequest) error {
+	s := r.snapshot
+	if _, err := r.GetRepo(ctx, s.RepoOwner, s.RepoName); err != nil {
+		return fmt.Errorf("get the repository: %w", err)
+	}
+	if _, err := r.ListPRFiles(ctx, s.RepoOwner, s.RepoName, s.PRNumber); err != nil {
+		return fmt.Errorf("list pull request files: %w", err)
+	}
+	if _, err := r.GetCodeOwners(ctx, s.RepoOwner, s.RepoName, pr.BaseSHA); err != nil {
+		return fmt.Errorf("get CODEOWNERS: %w", err)
+	}
+	if _, err := r.GetDefaultBranchFile(ctx, s.RepoOwner, s.RepoName, config.RepoFilePath); err != nil {
+		return fmt.Errorf("get the repository config file: %w", err)
+	}
+	return nil
+}
+
+func (r *recorder) GetPR(ctx context.Context, owner, name string, number int) (*github.PullRequest, error) {
+	pr, err := r.GitHub.GetPR(ctx, owner, name, number)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	// The pull request is copied because validation modifies it
+	b, err := json.Marshal(pr)
+	if err != nil {
+		return nil, fmt.Errorf("marshal the pull request: %w", err)
+	}
+	cp := &github.PullRequest{}
+	if err := json.Unmarshal(b, cp); err != nil {
+		return nil, fmt.Errorf("unmarshal the pull request: %w", err)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.snapshot.PullRequest == nil {
+		r.snapshot.PullRequest = cp
+	}
+	return pr, nil
+}
+
+func (r *recorder) CompareCommits(ctx context.Context, owner, repo, base, head string) ([]string, error) {
+	files, err := r.GitHub.CompareCommits(ctx, owner, repo, base, head)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshot.Comparisons[base+"..."+head] = files
+	return files, nil
+}
+
+func (r *recorder) IsAncestor(ctx context.Context, owner, repo, ancestor, descendant string) (bool, error) {
+	ok, err := r.GitHub.IsAncestor(ctx, owner, repo, ancestor, descendant)
+	if err != nil {
+		return false, err //nolint:wrapcheck
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshot.Ancestors[ancestor+"..."+descendant] = ok
+	return ok, nil
+}
+
+func (r *recorder) GetCodeOwners(ctx context.Context, owner, repo, ref string) (string, error) {
+	content, err := r.GitHub.GetCodeOwners(ctx, owner, repo, ref)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshot.CodeOwners[ref] = content
+	return content, nil
+}
+
+func (r *recorder) ListPRFiles(ctx context.Context, owner, repo string, number int) ([]string, error) {
+	files, err := r.GitHub.ListPRFiles(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshot.PRFiles = append([]string{}, files...)
+	return files, nil
+}
+
+func (r *recorder) ListTeamMembers(ctx context.Context, org, team string) ([]string, error) {
+	members, err := r.GitHub.ListTeamMembers(ctx, org, team)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshot.TeamMembers[org+"/"+team] = members
+	return members, nil
+}
+
+func (r *recorder) IsOrgMember(ctx context.Context, org, user string) (bool, error) {
+	ok, err := r.GitHub.IsOrgMember(ctx, org, user)
+	if err != nil {
+		return false, err //nolint:wrapcheck
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshot.OrgMembers[org+"/"+user] = ok
+	return ok, nil
+}
+
+func (r *recorder) GetRepo(ctx context.Context, owner, name string) (*github.Repository, error) {
+	repo, err := r.GitHub.GetRepo(ctx, owner, name)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshot.Repository = repo
+	return repo, nil
+}
+
+func (r *recorder) GetDefaultBranchFile(ctx context.Context, owner, repo, path string) (string, error) {
+	content, err := r.GitHub.GetDefaultBranchFile(ctx, owner, repo, path)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshot.DefaultBranchFiles[path] = content
+	return content, nil
+}
+
+// snapshotGitHub returns responses recorded in the snapshot instead of calling GitHub API.
+type snapshotGitHub struct {
+	snapshot *Snapshot
+}
+
+func (s *snapshotGitHub) GetPR(_ context.Context, _, _ string, _ int) (*github.PullRequest, error) {
+	if s.snapshot.PullRequest == nil {
+		return nil, fmt.Errorf("pull request: %w", errNotRecorded)
+	}
+	// The pull request is copied because validation modifies it
+	b, err := json.Marshal(s.snapshot.PullRequest)
+	if err != nil {
+		return nil, fmt.Errorf("marshal the pull request: %w", err)
+	}
+	pr := &github.PullRequest{}
+	if err := json.Unmarshal(b, pr); err != nil {
+		return nil, fmt.Errorf("unmarshal the pull request: %w", err)
+	}
+	return pr, nil
+}
+
+func (s *snapshotGitHub) CreateCheckRun(_ context.Context, _ githubv4.CreateCheckRunInput) error {
+	return errors.New("a check run can't be created from the snapshot")
+}
+
+func (s *snapshotGitHub) CompareCommits(_ context.Context, _, _, base, head string) ([]string, error) {
+	files, ok := s.snapshot.Comparisons[base+"..."+head]
+	if !ok {
+		return nil, fmt.Errorf("comparison %s...%s: %w", base, head, errNotRecorded)
+	}
+	return files, nil
+}
+
+func (s *snapshotGitHub) IsAncestor(_ context.Context, _, _, ancestor, descendant string) (bool, error) {
+	ok, found := s.snapshot.Ancestors[ancestor+"..."+descendant]
+	if !found {
+		return false, fmt.Errorf("ancestry %s...%s: %w", ancestor, descendant, errNotRecorded)
+	}
+	return ok, nil
+}
+
+func (s *snapshotGitHub) GetCodeOwners(_ context.Context, _, _, ref string) (string, error) {
+	content, ok := s.snapshot.CodeOwners[ref]
+	if !ok {
+		return "", fmt.Errorf("CODEOWNERS at %s: %w", ref, errNotRecorded)
+	}
+	return content, nil
+}
+
+func (s *snapshotGitHub) ListPRFiles(_ context.Context, _, _ string, _ int) ([]string, error) {
+	if s.snapshot.PRFiles == nil {
+		return nil, fmt.Errorf("pull request files: %w", errNotRecorded)
+	}
+	return s.snapshot.PRFiles, nil
+}
+
+func (s *snapshotGitHub) ListTeamMembers(_ context.Context, org, team string) ([]string, error) {
+	members, ok := s.snapshot.TeamMembers[org+"/"+team]
+	if !ok {
+		return nil, fmt.Errorf("members of the team %s/%s: %w", org, team, errMembershipNotRecorded)
+	}
+	return members, nil
+}
+
+func (s *snapshotGitHub) IsOrgMember(_ context.Context, org, user string) (bool, error) {
+	ok, found := s.snapshot.OrgMembers[org+"/"+user]
+	if !found {
+		return false, fmt.Errorf("membership of %s in the organization %s: %w", user, org, errMembershipNotRecorded)
+	}
+	return ok, nil
+}
+
+func (s *snapshotGitHub) GetRepo(_ context.Context, _, _ string) (*github.Repository, error) {
+	if s.snapshot.Repository == nil {
+		return nil, fmt.Errorf("repository metadata: %w", errNotRecorded)
+	}
+	return s.snapshot.Repository, nil
+}
+
+func (s *snapshotGitHub) GetDefaultBranchFile(_ context.Context, _, _, path string) (string, error) {
+	content, ok := s.snapshot.DefaultBranchFiles[path]
+	if !ok {
+		return "", fmt.Errorf("the file %s on the default branch: %w", path, errNotRecorded)
+	}
+	return content, nil
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
+)
+
+func TestSnapshot(t *testing.T) { //nolint:funlen,cyclop
+	t.Parallel()
+	gh := &mockGitHub{
+		pr: &github.PullRequest{
+			HeadSHA: "head",
+			BaseSHA: "base",
+			BaseRef: "main",
+			Approvers: map[string]*github.User{
+				"carol": {Login: "carol"},
+			},
+			Commits: []*github.Commit{
+				{
+					SHA:       "head",
+					Committer: &github.User{Login: "alice"},
+					Signature: &github.Signature{IsValid: true, State: "VALID"},
+				},
+			},
+		},
+		prFiles:     []string{"main.go"},
+		codeOwners:  map[string]string{"base": "* @org/reviewers\n"},
+		teamMembers: map[string][]string{"org/reviewers": {"carol"}},
+	}
+	newConfig := func(t *testing.T, s string) *config.Config {
+		t.Helper()
+		cfg := &config.Config{}
+		if err := config.Parse(cfg, []byte("app_id: 1\ninstallation_id: 2\n"+s)); err != nil {
+			t.Fatal(err)
+		}
+		return cfg
+	}
+
+	// record
+	c := &Controller{
+		input:     &InputNew{Config: newConfig(t, "require_code_owner_approvals: true\n")},
+		gh:        gh,
+		validator: validation.New(&validation.InputNew{}),
+	}
+	recorded, err := c.Evaluate(t.Context(), discardLogger, &EvaluateInput{RepoOwner: "org", RepoName: "repo", PRNumber: 1, Record: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded.Result.State != validation.StateApproved {
+		t.Fatalf("State = %s, want approved", recorded.Result.State)
+	}
+	b, err := json.Marshal(recorded.Snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		cfg       string
+		wantState validation.State
+		wantErr   error
+	}{
+		{
+			name:      "same config",
+			cfg:       "require_code_owner_approvals: true\n",
+			wantState: validation.StateApproved,
+		},
+		{
+			name:      "other config",
+			cfg:       "required_approvals: 2\n",
+			wantState: validation.StateTwoApprovalsAreRequired,
+		},
+		{
+			name:    "team isn't recorded",
+			cfg:     "trust:\n  approver_teams: [org/admins]\n",
+			wantErr: errMembershipNotRecorded,
+		},
+		{
+			name:    "organization isn't recorded",
+			cfg:     "trust:\n  approver_orgs: [other-org]\n",
+			wantErr: errMembershipNotRecorded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			snapshot := &Snapshot{}
+			if err := json.Unmarshal(b, snapshot); err != nil {
+				t.Fatal(err)
+			}
+			c := NewFromSnapshot(&InputNew{Config: newConfig(t, tt.cfg)}, snapshot)
+			got, err := c.Evaluate(t.Context(), discardLogger, &EvaluateInput{RepoOwner: snapshot.RepoOwner, RepoName: snapshot.RepoName, PRNumber: snapshot.PRNumber})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantErr != nil {
+				if !strings.Contains(got.Result.Error, tt.wantErr.Error()) {
+					t.Fatalf("the validation must fail: %+v", got.Result)
+				}
+				return
+			}
+			if got.Result.Error != "" {
+				t.Fatal(got.Result.Error)
+			}
+			if got.Result.State != tt.wantState {
+				t.Errorf("State = %s, want %s", got.Result.State, tt.wantState)
+			}
+		})
+	}
+}
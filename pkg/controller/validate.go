@@ -5,40 +5,100 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/codeowners"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
 )
 
-func (c *Controller) validate(ctx context.Context, logger *slog.Logger, ev *Event, trust *config.Trust, insecure *config.Insecure) *validation.Result {
-	pr, err := c.gh.GetPR(ctx, ev.RepoOwner, ev.RepoName, ev.PRNumber)
+func (c *Controller) validate(ctx context.Context, logger *slog.Logger, ev *Event, policy *config.Policy) *validation.Result {
+	pr, err := c.getPR(ctx, ev)
 	if err != nil {
 		return &validation.Result{Error: fmt.Errorf("get a pull request: %w", err).Error()}
 	}
 	logger.Info("fetched a pull request", "pull_request", pr)
 
+	// The policy is chosen by the base branch in the event.
+	// If the base branch has been changed since then, the pull_request edited event re-validates the pull request.
+	if ev.BaseRef != "" && pr.BaseRef != "" && ev.BaseRef != pr.BaseRef {
+		logger.Info("ignoring stale webhook: event base branch does not match current PR base branch",
+			"event_base_ref", ev.BaseRef, "base_ref", pr.BaseRef)
+		return nil
+	}
+
 	c.checkApproverCommits(ctx, logger, ev, pr)
 
-	input := &validation.Input{
-		PR: pr,
-		Trust: &validation.Trust{
-			TrustedApps:           trust.UniqueTrustedApps,
-			UntrustedMachineUsers: trust.UntrustedMachineUsers,
-		},
-	}
-	if insecure != nil {
-		input.Insecure = &validation.Insecure{
-			AllowUnsignedCommits:       insecure.AllowUnsignedCommits != nil && *insecure.AllowUnsignedCommits,
-			UnsignedCommitApps:         toSet(insecure.UnsignedCommitApps),
-			UnsignedCommitMachineUsers: toSet(insecure.UnsignedCommitMachineUsers),
-		}
+	input, err := c.newValidationInput(ctx, logger, ev, pr, policy)
+	if err != nil {
+		return &validation.Result{Error: err.Error()}
 	}
 	return c.validator.Run(logger, input)
 }
 
-func toSet(s []string) map[string]struct{} {
-	m := make(map[string]struct{}, len(s))
-	for _, v := range s {
-		m[v] = struct{}{}
+func (c *Controller) newValidationInput(ctx context.Context, logger *slog.Logger, ev *Event, pr *github.PullRequest, policy *config.Policy) (*validation.Input, error) {
+	input := policy.NewInput(pr)
+	members := newMembership(c.gh)
+	untrustedMachineUsers, err := members.resolveTeams(ctx, policy.Trust.UntrustedMachineUsersMatcher)
+	if err != nil {
+		return nil, fmt.Errorf("resolve teams of untrusted machine users: %w", err)
+	}
+	input.Trust.UntrustedMachineUsers = untrustedMachineUsers
+	if insecure := policy.Insecure; insecure != nil {
+		unsignedCommitMachineUsers, err := members.resolveTeams(ctx, insecure.UnsignedCommitMachineUsersMatcher)
+		if err != nil {
+			return nil, fmt.Errorf("resolve teams of unsigned commit machine users: %w", err)
+		}
+		input.Insecure.UnsignedCommitMachineUsers = unsignedCommitMachineUsers
+	}
+	if policy.Trust.RestrictsApprovers() {
+		approverMembers, err := members.getApproverMembers(ctx, policy.Trust, pr)
+		if err != nil {
+			return nil, fmt.Errorf("get approvers belonging to approver teams or organizations: %w", err)
+		}
+		input.Trust.ApproverMembers = approverMembers
+	}
+	if !policy.RequireCodeOwnerApprovals && len(policy.SensitivePaths) == 0 {
+		return input, nil
+	}
+	files, err := c.gh.ListPRFiles(ctx, ev.RepoOwner, ev.RepoName, ev.PRNumber)
+	if err != nil {
+		return nil, fmt.Errorf("list pull request files: %w", err)
+	}
+	sensitiveFiles, err := matchSensitiveFiles(policy.SensitivePaths, files)
+	if err != nil {
+		return nil, err
+	}
+	input.SensitiveFiles = sensitiveFiles
+	if policy.RequireCodeOwnerApprovals {
+		codeOwners, ignored, err := c.getCodeOwners(ctx, logger, ev, pr, files, members)
+		if err != nil {
+			return nil, fmt.Errorf("get code owners: %w", err)
+		}
+		input.CodeOwners = codeOwners
+		input.IgnoredCodeOwnersLines = ignored
+	}
+	return input, nil
+}
+
+// matchSensitiveFiles returns changed files matching any of sensitive path patterns.
+// Patterns use the same syntax as CODEOWNERS.
+func matchSensitiveFiles(patterns, files []string) ([]string, error) {
+	compiled := make([]*codeowners.Pattern, len(patterns))
+	for i, pattern := range patterns {
+		p, err := codeowners.CompilePattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("compile a sensitive path pattern %q: %w", pattern, err)
+		}
+		compiled[i] = p
+	}
+	var matched []string
+	for _, file := range files {
+		for _, p := range compiled {
+			if p.Match(file) {
+				matched = append(matched, file)
+				break
+			}
+		}
 	}
-	return m
+	return matched, nil
 }
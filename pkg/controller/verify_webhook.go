@@ -37,6 +37,11 @@ func (c *Controller) verifySignature(body []byte, headers map[string]string) err
 	return c.validateSignature(sig, body, c.input.WebhookSecret)
 }
 
+// VerifySignature verifies the webhook signature of the request.
+func (c *Controller) VerifySignature(req *Request) error {
+	return c.verifySignature([]byte(req.Body), c.normalizeHeaders(req.Headers))
+}
+
 func (c *Controller) normalizeHeaders(headers map[string]string) map[string]string {
 	hs := make(map[string]string, len(headers))
 	for k, v := range headers {
@@ -103,6 +108,12 @@ type Event struct {
 	ReviewState  string
 	RepoID       string
 	HeadSHA      string
+	// BaseRef is the base branch name of the pull request such as main
+	BaseRef string
+	// BaseChanged is true if the base branch of the pull request is edited
+	BaseChanged bool
+	// pr is the pull request fetched in advance. If it's nil, the pull request is fetched in validation
+	pr *github.PullRequest
 }
 
 func newPullRequestReviewEvent(ev *github.PullRequestReviewEvent) *Event {
@@ -116,6 +127,7 @@ func newPullRequestReviewEvent(ev *github.PullRequestReviewEvent) *Event {
 		ReviewState:  ev.GetReview().GetState(),
 		RepoID:       ev.GetRepo().GetNodeID(),
 		HeadSHA:      ev.GetPullRequest().GetHead().GetSHA(),
+		BaseRef:      ev.GetPullRequest().GetBase().GetRef(),
 	}
 }
 
@@ -129,7 +141,19 @@ func newPullRequestEvent(ev *github.PullRequestEvent) *Event {
 		PRNumber:     ev.GetPullRequest().GetNumber(),
 		RepoID:       ev.GetRepo().GetNodeID(),
 		HeadSHA:      ev.GetPullRequest().GetHead().GetSHA(),
+		BaseRef:      ev.GetPullRequest().GetBase().GetRef(),
+		BaseChanged:  ev.GetChanges().GetBase() != nil,
+	}
+}
+
+// getBaseRefFromBranch returns the base branch of a gh-readonly-queue branch.
+// e.g. gh-readonly-queue/release/v1/pr-24-a9d10f59f8c051673f45263c42aca8346614e716 => release/v1
+func getBaseRefFromBranch(branch string) string {
+	branch, ok := strings.CutPrefix(branch, "gh-readonly-queue/")
+	if !ok {
+		return ""
 	}
+	return path.Dir(branch)
 }
 
 func getPRNumberFromBranch(logger *slog.Logger, branch string) (int, error) {
@@ -175,5 +199,6 @@ func newCheckSuiteEvent(logger *slog.Logger, ev *github.CheckSuiteEvent) (*Event
 		PRNumber:     prNumber,
 		RepoID:       ev.GetRepo().GetNodeID(),
 		HeadSHA:      ev.GetCheckSuite().GetHeadSHA(),
+		BaseRef:      getBaseRefFromBranch(ev.GetCheckSuite().GetHeadBranch()),
 	}, nil
 }
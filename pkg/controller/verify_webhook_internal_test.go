@@ -256,3 +256,35 @@ func Test_getPRNumberFromBranch(t *testing.T) {
 		})
 	}
 }
+
+func Test_getBaseRefFromBranch(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		branch string
+		want   string
+	}{
+		{
+			name:   "main",
+			branch: "gh-readonly-queue/main/pr-24-a9d10f59f8c051673f45263c42aca8346614e716",
+			want:   "main",
+		},
+		{
+			name:   "base branch with slashes",
+			branch: "gh-readonly-queue/release/v1/pr-24-a9d10f59f8c051673f45263c42aca8346614e716",
+			want:   "release/v1",
+		},
+		{
+			name:   "not a gh-readonly-queue branch",
+			branch: "main",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := getBaseRefFromBranch(tt.branch); got != tt.want {
+				t.Errorf("getBaseRefFromBranch() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
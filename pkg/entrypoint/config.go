@@ -0,0 +1,145 @@
+package entrypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	"gopkg.in/yaml.v3"
+)
+
+// LintConfig initializes the config and outputs issues found by config.Config.Lint.
+// If path is empty, the config is read from the environment variable CONFIG or CONFIG_FILE.
+// It returns an error if any issue is found.
+func LintConfig(ctx context.Context, w io.Writer, getEnv func(string) string, path string) error {
+	cfg, err := readConfigFile(ctx, getEnv, path)
+	if err != nil {
+		return err
+	}
+	issues := cfg.Lint()
+	for _, issue := range issues {
+		fmt.Fprintln(w, issue)
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("the config has %d issues", len(issues))
+	}
+	return nil
+}
+
+// PrintEffective outputs the effective trust and insecure settings of the repository as YAML.
+// They're merged from the root config and repository configs matching the repository.
+// If repository configs have selectors, metadata of the repository is fetched with the GitHub App.
+// If the environment variable GITHUB_TOKEN is set, it's used instead of the GitHub App.
+func PrintEffective(ctx context.Context, logger *slog.Logger, w io.Writer, getEnv func(string) string, repo string) error {
+	cfg, err := readConfig(ctx, getEnv)
+	if err != nil {
+		return err
+	}
+	meta, err := getRepoMetadata(ctx, logger, cfg, getEnv("GITHUB_TOKEN"), repo)
+	if err != nil {
+		return err
+	}
+	r, err := cfg.GetEffectiveRepo(meta)
+	if err != nil {
+		return fmt.Errorf("get the effective config of the repository: %w", err)
+	}
+	return encodeYAML(w, &struct {
+		Trust    *config.Trust    `yaml:"trust"`
+		Insecure *config.Insecure `yaml:"insecure"`
+	}{
+		Trust:    r.Trust,
+		Insecure: r.Insecure,
+	})
+}
+
+// DiffConfig outputs changes of effective settings per repository name pattern between the config files.
+func DiffConfig(w io.Writer, getEnv func(string) string, oldPath, newPath string) error {
+	oldCfg := &config.Config{}
+	if err := config.ParseFile(oldCfg, oldPath, getEnv); err != nil {
+		return fmt.Errorf("read the old config: %w", err)
+	}
+	newCfg := &config.Config{}
+	if err := config.ParseFile(newCfg, newPath, getEnv); err != nil {
+		return fmt.Errorf("read the new config: %w", err)
+	}
+	diffs, err := config.Diff(oldCfg, newCfg)
+	if err != nil {
+		return fmt.Errorf("compare the configs: %w", err)
+	}
+	for _, diff := range diffs {
+		fmt.Fprintln(w, diff.Pattern)
+		for _, change := range diff.Changes {
+			fmt.Fprintf(w, "  %s: %s -> %s\n", change.Setting, settingValue(change.Old), settingValue(change.New))
+		}
+	}
+	return nil
+}
+
+// getRepoMetadata returns metadata of the repository if repository configs have selectors.
+// If token is empty, the secret of the GitHub App is read.
+func getRepoMetadata(ctx context.Context, logger *slog.Logger, cfg *config.Config, token, repo string) (*github.Repository, error) {
+	if !cfg.UsesRepoSelectors() {
+		return &github.Repository{FullName: repo}, nil
+	}
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok {
+		return nil, fmt.Errorf("repository must be in the format <owner>/<repo>: %q", repo)
+	}
+	var keyFile string
+	if token == "" {
+		s, err := readSecret(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		keyFile = s.GitHubAppPrivateKey
+	}
+	gh, err := github.New(&github.ParamNewApp{
+		AppID:          cfg.AppID,
+		InstallationID: cfg.InstallationID,
+		KeyFile:        keyFile,
+		Token:          token,
+		Logger:         logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create GitHub client: %w", err)
+	}
+	meta, err := gh.GetRepo(ctx, owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("get metadata of the repository: %w", err)
+	}
+	return meta, nil
+}
+
+func settingValue(v string) string {
+	if v == "" {
+		return "(unset)"
+	}
+	return v
+}
+
+func readConfigFile(ctx context.Context, getEnv func(string) string, path string) (*config.Config, error) {
+	if path == "" {
+		return readConfig(ctx, getEnv)
+	}
+	cfg := &config.Config{}
+	if err := config.ParseFile(cfg, path, getEnv); err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	return cfg, nil
+}
+
+func encodeYAML(w io.Writer, v any) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2) //nolint:mnd
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encode the config as YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("close the YAML encoder: %w", err)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/aws"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/capture"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/controller"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/gcloud"
@@ -15,8 +16,12 @@ import (
 )
 
 func Run(ctx context.Context, logger *slog.Logger, logLevel *slog.LevelVar, getEnv func(string) string, version string) error {
+	src, err := newConfigSource(ctx, getEnv)
+	if err != nil {
+		return err
+	}
 	cfg := &config.Config{}
-	if err := config.Read(cfg); err != nil {
+	if err := src.Read(ctx, cfg); err != nil {
 		return fmt.Errorf("read config: %w", err)
 	}
 	if err := logging.SetLevel(logLevel, cfg.LogLevel); err != nil {
@@ -39,10 +44,16 @@ func Run(ctx context.Context, logger *slog.Logger, logLevel *slog.LevelVar, getE
 	if err != nil {
 		return fmt.Errorf("create controller: %w", err)
 	}
+	var runner capture.Runner = ctrl
+	if p := getEnv("WEBHOOK_CAPTURE"); p != "" {
+		// Webhook requests are captured to replay them later
+		logger.Info("capturing webhook requests", "path", p)
+		runner = capture.NewController(ctrl, capture.NewWriter(p))
+	}
 
 	if getEnv("AWS_LAMBDA_FUNCTION_NAME") != "" {
 		// lambda
-		handler, err := aws.NewHandler(logger, ctrl, cfg)
+		handler, err := aws.NewHandler(logger, logLevel, runner, cfg, src)
 		if err != nil {
 			return fmt.Errorf("create a new handler: %w", err)
 		}
@@ -51,7 +62,7 @@ func Run(ctx context.Context, logger *slog.Logger, logLevel *slog.LevelVar, getE
 	}
 
 	// http server
-	server, err := server.New(logger, ctrl, cfg)
+	server, err := server.New(logger, logLevel, runner, cfg, src)
 	if err != nil {
 		return fmt.Errorf("create a new server: %w", err)
 	}
@@ -59,6 +70,32 @@ func Run(ctx context.Context, logger *slog.Logger, logLevel *slog.LevelVar, getE
 	return nil
 }
 
+// newConfigSource returns the source of the config.
+// If CONFIG_FILE is a URL of Amazon S3 or Google Cloud Storage, the client of the storage is created.
+func newConfigSource(ctx context.Context, getEnv func(string) string) (*config.Source, error) {
+	u, err := config.ObjectURLFromEnv(getEnv)
+	if err != nil {
+		return nil, fmt.Errorf("parse CONFIG_FILE: %w", err)
+	}
+	if u == nil {
+		return config.NewSource(nil, nil, getEnv), nil
+	}
+	switch u.Scheme {
+	case config.SchemeS3:
+		s3, err := aws.NewS3(ctx, getEnv)
+		if err != nil {
+			return nil, fmt.Errorf("create S3 client: %w", err)
+		}
+		return config.NewSource(u, s3, getEnv), nil
+	default:
+		gcs, err := gcloud.NewStorage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("create Cloud Storage client: %w", err)
+		}
+		return config.NewSource(u, gcs, getEnv), nil
+	}
+}
+
 func readSecret(ctx context.Context, cfg *config.Config) (*secret.Secret, error) {
 	if cfg.AWS != nil && cfg.AWS.SecretID != "" {
 		secret, err := aws.ReadSecret(ctx, cfg.AWS.SecretID)
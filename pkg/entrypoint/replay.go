@@ -0,0 +1,182 @@
+package entrypoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/capture"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/controller"
+)
+
+const replayTimeout = 30 * time.Second
+
+// ReplayInput is the input of Replay.
+type ReplayInput struct {
+	// Path is the JSONL file or the directory of captured webhook requests
+	Path string
+	// URL is the URL to which requests are posted. If it's empty, requests are handled by the controller in the process
+	URL    string
+	DryRun bool
+	Format string
+}
+
+// replayResult is the result of a replayed request.
+type replayResult struct {
+	RequestID string `json:"request_id,omitempty"`
+	Event     string `json:"event"`
+	// Status is the HTTP status code of the response from URL
+	Status     int                    `json:"status,omitempty"`
+	Evaluation *controller.Evaluation `json:"evaluation,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+}
+
+// Replay re-signs captured webhook requests with the environment variable WEBHOOK_SECRET and replays them.
+// If input.URL is set, requests are posted to the URL.
+// Otherwise, they are handled with the config like the server.
+// If input.DryRun is true, decisions are output without creating check runs.
+func Replay(ctx context.Context, logger *slog.Logger, w io.Writer, getEnv func(string) string, version string, input *ReplayInput) error {
+	if err := validateFormat(input.Format); err != nil {
+		return err
+	}
+	if input.URL != "" && input.DryRun {
+		return errors.New("--dry-run can't be used with --url")
+	}
+	secret := getEnv("WEBHOOK_SECRET")
+	if secret == "" {
+		return errors.New("the environment variable WEBHOOK_SECRET is required to sign requests")
+	}
+	deliveries, err := capture.Read(input.Path)
+	if err != nil {
+		return fmt.Errorf("read captured requests: %w", err)
+	}
+	handle, err := newReplayHandler(ctx, logger, getEnv, version, secret, input)
+	if err != nil {
+		return err
+	}
+	failed := 0
+	for i, d := range deliveries {
+		req := d.Sign([]byte(secret))
+		result := handle(ctx, req)
+		result.RequestID = d.RequestID
+		result.Event = headerValue(d.Headers, "X-GitHub-Event")
+		if result.Error != "" {
+			failed++
+		}
+		if err := outputReplayResult(w, input.Format, i, len(deliveries), result); err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d requests failed", failed, len(deliveries))
+	}
+	return nil
+}
+
+func newReplayHandler(ctx context.Context, logger *slog.Logger, getEnv func(string) string, version, secret string, input *ReplayInput) (func(context.Context, *controller.Request) *replayResult, error) {
+	if input.URL != "" {
+		client := &http.Client{Timeout: replayTimeout}
+		return func(ctx context.Context, req *controller.Request) *replayResult {
+			return post(ctx, client, input.URL, req)
+		}, nil
+	}
+	cfg, err := readConfig(ctx, getEnv)
+	if err != nil {
+		return nil, err
+	}
+	param := &controller.InputNew{
+		Config:        cfg,
+		Version:       version,
+		WebhookSecret: []byte(secret),
+		GitHubToken:   getEnv("GITHUB_TOKEN"),
+		Logger:        logger,
+	}
+	if param.GitHubToken == "" {
+		s, err := readSecret(ctx, cfg)
+		if err != nil {
+			return nil, err
+		}
+		param.GitHubAppPrivateKey = s.GitHubAppPrivateKey
+	}
+	ctrl, err := controller.New(param)
+	if err != nil {
+		return nil, fmt.Errorf("create controller: %w", err)
+	}
+	if input.DryRun {
+		return func(ctx context.Context, req *controller.Request) *replayResult {
+			evaluation, err := ctrl.DryRun(ctx, logger, req)
+			if err != nil {
+				return &replayResult{Error: err.Error()}
+			}
+			return &replayResult{Evaluation: evaluation}
+		}, nil
+	}
+	return func(ctx context.Context, req *controller.Request) *replayResult {
+		if err := ctrl.Run(ctx, logger, req); err != nil {
+			return &replayResult{Error: err.Error()}
+		}
+		return &replayResult{}
+	}, nil
+}
+
+func post(ctx context.Context, client *http.Client, url string, req *controller.Request) *replayResult {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBufferString(req.Body))
+	if err != nil {
+		return &replayResult{Error: fmt.Errorf("create a request: %w", err).Error()}
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return &replayResult{Error: fmt.Errorf("send a request: %w", err).Error()}
+	}
+	defer resp.Body.Close()
+	result := &replayResult{Status: resp.StatusCode}
+	if resp.StatusCode >= http.StatusBadRequest {
+		result.Error = "the server returned " + resp.Status
+	}
+	return result
+}
+
+func outputReplayResult(w io.Writer, format string, i, total int, result *replayResult) error {
+	if format == FormatJSON {
+		b, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("encode the result as JSON: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err //nolint:wrapcheck
+	}
+	header := fmt.Sprintf("[%d/%d] event=%s request_id=%s", i+1, total, result.Event, result.RequestID)
+	switch {
+	case result.Error != "":
+		header += " error: " + result.Error
+	case result.Status != 0:
+		header += fmt.Sprintf(" status=%d", result.Status)
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return err //nolint:wrapcheck
+	}
+	if result.Evaluation == nil {
+		return nil
+	}
+	return printEvaluation(w, result.Evaluation)
+}
+
+// headerValue returns the value of the header. Header names are case-insensitive.
+func headerValue(headers map[string]string, key string) string {
+	for k, v := range headers {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
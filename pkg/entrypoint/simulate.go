@@ -0,0 +1,314 @@
+package entrypoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/controller"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+)
+
+// reasonOther groups changed outcomes without reasons, for example because the repository is ignored.
+const reasonOther = "other"
+
+// SimulateInput is the input of Simulate.
+type SimulateInput struct {
+	// Candidate is the path of the candidate config file
+	Candidate string
+	// Targets are repositories like <owner>/<repo> or pull requests like <owner>/<repo>#<number>.
+	// Recently merged pull requests of repositories are simulated
+	Targets []string
+	// Limit is the maximum number of merged pull requests per repository. They are ordered by the last update
+	Limit int
+	// Cache is the directory where snapshots of pull requests are saved. Cached pull requests are read without GitHub
+	Cache string
+	// Offline reads pull requests only from Cache
+	Offline bool
+	Format  string
+}
+
+// pullRequest is a pull request to be simulated.
+type pullRequest struct {
+	owner  string
+	repo   string
+	number int
+}
+
+// simulationGroup is pull requests of the repository whose outcomes are changed by the reason.
+type simulationGroup struct {
+	Repository   string                `json:"repository"`
+	Reason       string                `json:"reason"`
+	PullRequests []*changedPullRequest `json:"pull_requests"`
+}
+
+type changedPullRequest struct {
+	Number    int    `json:"number"`
+	Current   string `json:"current"`
+	Candidate string `json:"candidate"`
+}
+
+type simulationReport struct {
+	Simulated int                `json:"simulated"`
+	Changed   int                `json:"changed"`
+	Groups    []*simulationGroup `json:"groups"`
+}
+
+// Simulate validates pull requests with the current config and the candidate config and outputs pull requests whose outcomes are changed.
+// The current config is read from the environment variable CONFIG or CONFIG_FILE like the server.
+// Check runs aren't created.
+func Simulate(ctx context.Context, logger *slog.Logger, w io.Writer, getEnv func(string) string, version string, input *SimulateInput) error {
+	if err := validateFormat(input.Format); err != nil {
+		return err
+	}
+	if input.Limit <= 0 {
+		return errors.New("--limit must be greater than 0")
+	}
+	if input.Offline && input.Cache == "" {
+		return errors.New("--offline requires --cache")
+	}
+	current, err := readConfig(ctx, getEnv)
+	if err != nil {
+		return err
+	}
+	candidate := &config.Config{}
+	if err := config.ParseFile(candidate, input.Candidate, getEnv); err != nil {
+		return fmt.Errorf("read the candidate config: %w", err)
+	}
+	param := &controller.InputNew{
+		Config:  current,
+		Version: version,
+		Logger:  logger,
+	}
+	s := &simulator{
+		logger:    logger,
+		param:     param,
+		candidate: candidate,
+		input:     input,
+	}
+	if !input.Offline {
+		if err := s.connect(ctx, getEnv); err != nil {
+			return err
+		}
+	}
+	prs, err := s.listPRs(ctx)
+	if err != nil {
+		return err
+	}
+	simulations := make([]*controller.Simulation, 0, len(prs))
+	for _, pr := range prs {
+		simulation, err := s.simulate(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("simulate %s/%s#%d: %w", pr.owner, pr.repo, pr.number, err)
+		}
+		simulations = append(simulations, simulation)
+	}
+	report := newSimulationReport(simulations)
+	if input.Format == FormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("encode the result as JSON: %w", err)
+		}
+		return nil
+	}
+	return printSimulationReport(w, report)
+}
+
+type simulator struct {
+	logger    *slog.Logger
+	param     *controller.InputNew
+	candidate *config.Config
+	input     *SimulateInput
+	gh        *github.Client
+	ctrl      *controller.Controller
+}
+
+// connect creates clients of GitHub.
+// If the environment variable GITHUB_TOKEN is set, it's used instead of the GitHub App.
+func (s *simulator) connect(ctx context.Context, getEnv func(string) string) error {
+	s.param.GitHubToken = getEnv("GITHUB_TOKEN")
+	if s.param.GitHubToken == "" {
+		secret, err := readSecret(ctx, s.param.Config)
+		if err != nil {
+			return err
+		}
+		s.param.GitHubAppPrivateKey = secret.GitHubAppPrivateKey
+	}
+	gh, err := github.New(&github.ParamNewApp{
+		AppID:          s.param.Config.AppID,
+		InstallationID: s.param.Config.InstallationID,
+		KeyFile:        s.param.GitHubAppPrivateKey,
+		Token:          s.param.GitHubToken,
+		Logger:         s.logger,
+	})
+	if err != nil {
+		return fmt.Errorf("create GitHub client: %w", err)
+	}
+	ctrl, err := controller.New(s.param)
+	if err != nil {
+		return fmt.Errorf("create controller: %w", err)
+	}
+	s.gh = gh
+	s.ctrl = ctrl
+	return nil
+}
+
+// listPRs returns pull requests of targets.
+// Merged pull requests of repositories are listed from GitHub, or from the cache if it's offline.
+func (s *simulator) listPRs(ctx context.Context) ([]*pullRequest, error) {
+	var prs []*pullRequest
+	for _, target := range s.input.Targets {
+		if strings.Contains(target, "#") {
+			owner, repo, number, err := ParsePRRef(target)
+			if err != nil {
+				return nil, err
+			}
+			prs = append(prs, &pullRequest{owner: owner, repo: repo, number: number})
+			continue
+		}
+		owner, repo, ok := strings.Cut(target, "/")
+		if !ok || owner == "" || repo == "" {
+			return nil, fmt.Errorf("the target must be in the format <owner>/<repo> or <owner>/<repo>#<number>: %q", target)
+		}
+		numbers, err := s.listMergedPRs(ctx, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		for _, number := range numbers {
+			prs = append(prs, &pullRequest{owner: owner, repo: repo, number: number})
+		}
+	}
+	return prs, nil
+}
+
+func (s *simulator) listMergedPRs(ctx context.Context, owner, repo string) ([]int, error) {
+	if !s.input.Offline {
+		numbers, err := s.gh.ListMergedPRs(ctx, owner, repo, s.input.Limit)
+		if err != nil {
+			return nil, fmt.Errorf("list merged pull requests of %s/%s: %w", owner, repo, err)
+		}
+		return numbers, nil
+	}
+	entries, err := os.ReadDir(filepath.Join(s.input.Cache, owner, repo))
+	if err != nil {
+		return nil, fmt.Errorf("read cached pull requests of %s/%s: %w", owner, repo, err)
+	}
+	var numbers []int
+	for _, entry := range entries {
+		number, err := strconv.Atoi(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil || entry.IsDir() {
+			continue
+		}
+		numbers = append(numbers, number)
+	}
+	// Pull requests with larger numbers are likely to be merged more recently
+	slices.Sort(numbers)
+	slices.Reverse(numbers)
+	return numbers[:min(len(numbers), s.input.Limit)], nil
+}
+
+// simulate simulates the pull request.
+// If the pull request is cached, it's read from the cache. Otherwise, it's fetched from GitHub and cached.
+func (s *simulator) simulate(ctx context.Context, pr *pullRequest) (*controller.Simulation, error) {
+	input := &controller.SimulateInput{
+		RepoOwner: pr.owner,
+		RepoName:  pr.repo,
+		PRNumber:  pr.number,
+	}
+	if s.input.Cache == "" {
+		return s.ctrl.Simulate(ctx, s.logger, s.candidate, input) //nolint:wrapcheck
+	}
+	path := filepath.Join(s.input.Cache, pr.owner, pr.repo, strconv.Itoa(pr.number)+".json")
+	if _, err := os.Stat(path); err == nil {
+		snapshot, err := readSnapshot(path)
+		if err != nil {
+			return nil, err
+		}
+		return controller.NewFromSnapshot(s.param, snapshot).Simulate(ctx, s.logger, s.candidate, input) //nolint:wrapcheck
+	}
+	if s.input.Offline {
+		return nil, fmt.Errorf("the pull request isn't cached: %s", path)
+	}
+	input.Record = true
+	simulation, err := s.ctrl.Simulate(ctx, s.logger, s.candidate, input)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:mnd
+		return nil, fmt.Errorf("create the cache directory: %w", err)
+	}
+	if err := writeSnapshot(path, simulation.Snapshot); err != nil {
+		return nil, err
+	}
+	return simulation, nil
+}
+
+// newSimulationReport groups pull requests whose outcomes are changed by repository and reason.
+// A pull request changed by multiple reasons belongs to all of their groups.
+func newSimulationReport(simulations []*controller.Simulation) *simulationReport {
+	report := &simulationReport{
+		Simulated: len(simulations),
+		Groups:    []*simulationGroup{},
+	}
+	groups := map[string]map[string]*simulationGroup{}
+	for _, simulation := range simulations {
+		if !simulation.Changed() {
+			continue
+		}
+		report.Changed++
+		reasons := simulation.Reasons()
+		if len(reasons) == 0 {
+			reasons = []string{reasonOther}
+		}
+		if groups[simulation.RepoFullName] == nil {
+			groups[simulation.RepoFullName] = map[string]*simulationGroup{}
+		}
+		for _, reason := range reasons {
+			group, ok := groups[simulation.RepoFullName][reason]
+			if !ok {
+				group = &simulationGroup{Repository: simulation.RepoFullName, Reason: reason}
+				groups[simulation.RepoFullName][reason] = group
+			}
+			group.PullRequests = append(group.PullRequests, &changedPullRequest{
+				Number:    simulation.PRNumber,
+				Current:   controller.Outcome(simulation.Current),
+				Candidate: controller.Outcome(simulation.Candidate),
+			})
+		}
+	}
+	for _, repo := range slices.Sorted(maps.Keys(groups)) {
+		for _, reason := range slices.Sorted(maps.Keys(groups[repo])) {
+			report.Groups = append(report.Groups, groups[repo][reason])
+		}
+	}
+	return report
+}
+
+func printSimulationReport(w io.Writer, report *simulationReport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Simulated %d pull requests. %d outcomes changed.\n", report.Simulated, report.Changed)
+	repo := ""
+	for _, group := range report.Groups {
+		if group.Repository != repo {
+			repo = group.Repository
+			fmt.Fprintf(&b, "\n%s\n", repo)
+		}
+		fmt.Fprintf(&b, "  %s\n", group.Reason)
+		for _, pr := range group.PullRequests {
+			fmt.Fprintf(&b, "    #%d %s -> %s\n", pr.Number, pr.Current, pr.Candidate)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err //nolint:wrapcheck
+}
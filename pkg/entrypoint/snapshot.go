@@ -0,0 +1,66 @@
+package entrypoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/controller"
+)
+
+// validateSnapshot validates the pull request recorded in the snapshot with the config.
+// It doesn't access GitHub, so the decision can be reproduced with any config.
+func validateSnapshot(ctx context.Context, logger *slog.Logger, cfg *config.Config, version string, input *ValidateInput) (*controller.Evaluation, error) {
+	if input.Apply || input.Snapshot != "" {
+		return nil, errors.New("--apply and --snapshot can't be used with --from-snapshot")
+	}
+	snapshot, err := readSnapshot(input.FromSnapshot)
+	if err != nil {
+		return nil, err
+	}
+	ctrl := controller.NewFromSnapshot(&controller.InputNew{
+		Config:  cfg,
+		Version: version,
+		Logger:  logger,
+	}, snapshot)
+	evaluation, err := ctrl.Evaluate(ctx, logger, &controller.EvaluateInput{
+		RepoOwner:    snapshot.RepoOwner,
+		RepoName:     snapshot.RepoName,
+		PRNumber:     snapshot.PRNumber,
+		CarryForward: input.CarryForward,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("evaluate the pull request: %w", err)
+	}
+	return evaluation, nil
+}
+
+func readSnapshot(path string) (*controller.Snapshot, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read the snapshot: %w", err)
+	}
+	snapshot := &controller.Snapshot{}
+	if err := json.Unmarshal(b, snapshot); err != nil {
+		return nil, fmt.Errorf("parse the snapshot: %w", err)
+	}
+	if snapshot.PullRequest == nil {
+		return nil, fmt.Errorf("the snapshot has no pull request: %s", path)
+	}
+	return snapshot, nil
+}
+
+func writeSnapshot(path string, snapshot *controller.Snapshot) error {
+	b, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal the snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil { //nolint:gosec,mnd
+		return fmt.Errorf("write the snapshot: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package entrypoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/controller"
+)
+
+// Output formats of Validate.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// ValidateInput is the input of Validate.
+type ValidateInput struct {
+	// PR is the pull request like <owner>/<repo>#<number>. It isn't used if FromSnapshot is set
+	PR     string
+	Format string
+	// Apply creates the check run with the result
+	Apply bool
+	// CarryForward evaluates the pull request as if new commits were pushed
+	CarryForward bool
+	// Snapshot is the path of the file to which the snapshot of the pull request is written
+	Snapshot string
+	// FromSnapshot is the path of the snapshot file. If it's set, the pull request is validated without GitHub
+	FromSnapshot string
+}
+
+// Validate validates the pull request with the config and outputs the result.
+// If input.FromSnapshot is set, the pull request is read from the snapshot instead of GitHub.
+// If the environment variable GITHUB_TOKEN is set, it's used instead of the GitHub App.
+// The check run isn't created unless input.Apply is true.
+func Validate(ctx context.Context, logger *slog.Logger, w io.Writer, getEnv func(string) string, version string, input *ValidateInput) error {
+	if err := validateFormat(input.Format); err != nil {
+		return err
+	}
+	if input.FromSnapshot != "" {
+		cfg, err := readConfig(ctx, getEnv)
+		if err != nil {
+			return err
+		}
+		evaluation, err := validateSnapshot(ctx, logger, cfg, version, input)
+		if err != nil {
+			return err
+		}
+		return outputEvaluation(w, input.Format, evaluation)
+	}
+	owner, repo, number, err := ParsePRRef(input.PR)
+	if err != nil {
+		return err
+	}
+	cfg, err := readConfig(ctx, getEnv)
+	if err != nil {
+		return err
+	}
+	param := &controller.InputNew{
+		Config:      cfg,
+		Version:     version,
+		GitHubToken: getEnv("GITHUB_TOKEN"),
+		Logger:      logger,
+	}
+	if param.GitHubToken == "" {
+		s, err := readSecret(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		param.GitHubAppPrivateKey = s.GitHubAppPrivateKey
+	}
+	ctrl, err := controller.New(param)
+	if err != nil {
+		return fmt.Errorf("create controller: %w", err)
+	}
+	evaluation, err := ctrl.Evaluate(ctx, logger, &controller.EvaluateInput{
+		RepoOwner:    owner,
+		RepoName:     repo,
+		PRNumber:     number,
+		CarryForward: input.CarryForward,
+		Apply:        input.Apply,
+		Record:       input.Snapshot != "",
+	})
+	if err != nil {
+		return fmt.Errorf("evaluate the pull request: %w", err)
+	}
+	if input.Snapshot != "" {
+		if err := writeSnapshot(input.Snapshot, evaluation.Snapshot); err != nil {
+			return err
+		}
+	}
+	return outputEvaluation(w, input.Format, evaluation)
+}
+
+func validateFormat(format string) error {
+	if format != FormatText && format != FormatJSON {
+		return fmt.Errorf("format must be %s or %s: %q", FormatText, FormatJSON, format)
+	}
+	return nil
+}
+
+func readConfig(ctx context.Context, getEnv func(string) string) (*config.Config, error) {
+	src, err := newConfigSource(ctx, getEnv)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config.Config{}
+	if err := src.Read(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	return cfg, nil
+}
+
+func outputEvaluation(w io.Writer, format string, evaluation *controller.Evaluation) error {
+	if format == FormatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(evaluation); err != nil {
+			return fmt.Errorf("encode the result as JSON: %w", err)
+		}
+		return nil
+	}
+	return printEvaluation(w, evaluation)
+}
+
+func printEvaluation(w io.Writer, evaluation *controller.Evaluation) error {
+	if evaluation.Result == nil {
+		_, err := fmt.Fprintln(w, "The pull request isn't validated. See the log for the reason.")
+		return err //nolint:wrapcheck
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "State: %s\n", evaluation.Result.State)
+	if evaluation.Result.Error != "" {
+		fmt.Fprintf(&b, "Error: %s\n", evaluation.Result.Error)
+	}
+	if reasons := evaluation.Result.Reasons(); len(reasons) > 0 {
+		fmt.Fprintf(&b, "Reasons: %s\n", strings.Join(reasons, ", "))
+	}
+	fmt.Fprintf(&b, "Conclusion: %s\n", evaluation.Conclusion)
+	fmt.Fprintf(&b, "Title: %s\n", evaluation.Title)
+	if evaluation.Applied {
+		b.WriteString("The check run was created.\n")
+	}
+	b.WriteString("\n" + evaluation.Summary + "\n")
+	_, err := io.WriteString(w, b.String())
+	return err //nolint:wrapcheck
+}
+
+// ParsePRRef parses the pull request reference like <owner>/<repo>#<number>.
+func ParsePRRef(s string) (string, string, int, error) {
+	fullName, num, ok := strings.Cut(s, "#")
+	if !ok {
+		return "", "", 0, fmt.Errorf("the pull request must be in the format <owner>/<repo>#<number>: %q", s)
+	}
+	owner, repo, ok := strings.Cut(fullName, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", 0, fmt.Errorf("the pull request must be in the format <owner>/<repo>#<number>: %q", s)
+	}
+	number, err := strconv.Atoi(num)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("parse the pull request number: %w", err)
+	}
+	if number < 1 {
+		return "", "", 0, errors.New("the pull request number must be positive")
+	}
+	return owner, repo, number, nil
+}
@@ -0,0 +1,43 @@
+package gcloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/storage/v1"
+)
+
+type Storage struct {
+	service *storage.Service
+}
+
+func NewStorage(ctx context.Context) (*Storage, error) {
+	svc, err := storage.NewService(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("create Cloud Storage client: %w", err)
+	}
+	return &Storage{service: svc}, nil
+}
+
+func (s *Storage) GetObject(ctx context.Context, bucket, key, etag string) ([]byte, string, error) {
+	call := s.service.Objects.Get(bucket, key).Context(ctx)
+	if etag != "" {
+		call.Header().Set("If-None-Match", etag)
+	}
+	resp, err := call.Download()
+	if err != nil {
+		if googleapi.IsNotModified(err) {
+			return nil, "", config.ErrNotModified
+		}
+		return nil, "", fmt.Errorf("get an object from Cloud Storage: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("read an object from Cloud Storage: %w", err)
+	}
+	return data, resp.Header.Get("ETag"), nil
+}
@@ -11,8 +11,9 @@ import (
 )
 
 type Client struct {
-	v4Client V4Client
-	v3Client V3Client
+	v4Client  V4Client
+	v3Client  V3Client
+	repoCache *repoCache
 }
 
 type V4Client interface {
@@ -23,6 +24,12 @@ type V4Client interface {
 type V3Client interface {
 	CompareCommits(ctx context.Context, owner, repo, base, head string) ([]string, error)
 	IsAncestor(ctx context.Context, owner, repo, ancestor, descendant string) (bool, error)
+	GetFileContent(ctx context.Context, owner, repo, path, ref string) (string, error)
+	ListPRFiles(ctx context.Context, owner, repo string, number int) ([]string, error)
+	ListMergedPRs(ctx context.Context, owner, repo string, limit int) ([]int, error)
+	ListTeamMembers(ctx context.Context, org, team string) ([]string, error)
+	IsOrgMember(ctx context.Context, org, user string) (bool, error)
+	GetRepo(ctx context.Context, owner, repo string) (*github.Repository, error)
 }
 
 type (
@@ -43,6 +50,7 @@ func New(param *v4.ParamNewApp) (*Client, error) {
 		AppID:          param.AppID,
 		InstallationID: param.InstallationID,
 		KeyFile:        param.KeyFile,
+		Token:          param.Token,
 		Logger:         param.Logger,
 	})
 	if err != nil {
@@ -51,5 +59,8 @@ func New(param *v4.ParamNewApp) (*Client, error) {
 	return &Client{
 		v4Client: v4Client,
 		v3Client: v3Client,
+		repoCache: &repoCache{
+			repos: map[string]*cachedRepo{},
+		},
 	}, nil
 }
@@ -0,0 +1,26 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/codeowners"
+	v3 "github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github/v3"
+)
+
+// GetCodeOwners gets the content of the CODEOWNERS file at the given ref.
+// It returns an empty string if the CODEOWNERS file doesn't exist.
+func (c *Client) GetCodeOwners(ctx context.Context, owner, repo, ref string) (string, error) {
+	for _, path := range codeowners.Paths {
+		content, err := c.v3Client.GetFileContent(ctx, owner, repo, path, ref)
+		if err != nil {
+			if errors.Is(err, v3.ErrNotFound) {
+				continue
+			}
+			return "", fmt.Errorf("get CODEOWNERS: %w", err)
+		}
+		return content, nil
+	}
+	return "", nil
+}
@@ -9,6 +9,19 @@ type UntrustedCommit struct {
 	IsUntrustedApp         bool
 	InvalidSign            *Signature
 	NotLinkedToUser        bool
+	// signature policy violations. InvalidSign is set to the signature
+	SignatureStateRejected bool
+	SignatureTypeRejected  bool
+	SignatureKeyRejected   bool
+	// the error of the signature verification with the keyring
+	KeyringError string
+	// the error of the gitsign signature verification
+	GitsignError string
+}
+
+// ViolatesSignaturePolicy returns true if the commit signature violates the signature policy.
+func (c *UntrustedCommit) ViolatesSignaturePolicy() bool {
+	return c.SignatureStateRejected || c.SignatureTypeRejected || c.SignatureKeyRejected || c.KeyringError != "" || c.GitsignError != ""
 }
 
 func (c *UntrustedCommit) Message() string {
@@ -24,6 +37,21 @@ func (c *UntrustedCommit) Message() string {
 	if c.IsUntrustedMachineUser {
 		return "The committer is an untrusted machine user."
 	}
+	if c.KeyringError != "" {
+		return "The commit signature isn't verified with the keyring. " + c.KeyringError
+	}
+	if c.GitsignError != "" {
+		return "The gitsign signature isn't verified. " + c.GitsignError
+	}
+	if c.SignatureStateRejected {
+		return "The commit signature state " + c.InvalidSign.State + " isn't accepted."
+	}
+	if c.SignatureTypeRejected {
+		return "The commit signature type " + c.InvalidSign.Type() + " isn't allowed."
+	}
+	if c.SignatureKeyRejected {
+		return "The signing key " + c.InvalidSign.Key() + " isn't allowed for " + c.Login + "."
+	}
 	if c.InvalidSign == nil {
 		return "The commit isn't signed."
 	}
@@ -36,6 +64,8 @@ func (c *UntrustedCommit) Message() string {
 type Commit struct {
 	SHA                     string        `json:"oid"`
 	Committer               *User         `json:"committer"`
+	Author                  *User         `json:"author"`
+	CoAuthors               []*User       `json:"co_authors"`
 	Signature               *v4.Signature `json:"signature"`
 	Parents                 []string      `json:"parents"`
 	ChangedFilesIfAvailable *int          `json:"changed_files_if_available"`
@@ -54,11 +84,41 @@ func newCommit(pc *v4.PullRequestCommit) *Commit {
 			parents[i] = p.OID
 		}
 	}
+	var author *User
+	if pc.Commit.Author != nil {
+		author = newUser(pc.Commit.Author.User)
+	}
 	return &Commit{
 		SHA:                     pc.Commit.OID,
 		Committer:               newUser(pc.Commit.User()),
+		Author:                  author,
+		CoAuthors:               newCoAuthors(pc.Commit.Authors, author),
 		Signature:               pc.Commit.Signature,
 		Parents:                 parents,
 		ChangedFilesIfAvailable: pc.Commit.ChangedFilesIfAvailable,
 	}
 }
+
+// newCoAuthors returns users of co-authors excluding the author.
+// Co-authors not linked to GitHub users are ignored.
+func newCoAuthors(authors *v4.GitActors, author *User) []*User {
+	if authors == nil {
+		return nil
+	}
+	var coAuthors []*User
+	seen := map[string]struct{}{}
+	if author != nil {
+		seen[author.Login] = struct{}{}
+	}
+	for _, node := range authors.Nodes {
+		if node == nil || node.User == nil {
+			continue
+		}
+		if _, ok := seen[node.User.Login]; ok {
+			continue
+		}
+		seen[node.User.Login] = struct{}{}
+		coAuthors = append(coAuthors, newUser(node.User))
+	}
+	return coAuthors
+}
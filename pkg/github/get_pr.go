@@ -8,6 +8,11 @@ import (
 
 type Signature = v4.Signature
 
+const (
+	SignatureTypeGPG  = v4.SignatureTypeGPG
+	SignatureTypeX509 = v4.SignatureTypeX509
+)
+
 // GetPR gets a pull request reviews and committers via GitHub GraphQL API.
 func (c *Client) GetPR(ctx context.Context, owner, name string, number int) (*PullRequest, error) {
 	pr, err := c.v4Client.GetPR(ctx, owner, name, number)
@@ -25,9 +30,12 @@ func (c *Client) GetPR(ctx context.Context, owner, name string, number int) (*Pu
 	p := &PullRequest{
 		HeadSHA:           pr.HeadRefOID,
 		BaseSHA:           pr.BaseRefOID,
+		BaseRef:           pr.BaseRefName,
 		Commits:           commits,
 		Approvers:         approversByCommit[pr.HeadRefOID],
 		ApproversByCommit: approversByCommit,
+		ChangesRequesters: buildChangesRequesters(pr.Reviews.Nodes),
+		Pushes:            newPushes(pr.TimelineItems),
 	}
 	if p.Approvers == nil {
 		p.Approvers = make(map[string]*User)
@@ -76,3 +84,28 @@ func buildApproversByCommit(reviewsByCommit map[string]map[string]*v4.Review) ma
 	}
 	return approversByCommit
 }
+
+// buildChangesRequesters returns reviewers whose latest review is CHANGES_REQUESTED.
+// Unlike approvals, a review requesting changes remains effective after new commits are pushed
+// until the reviewer approves the pull request or the review is dismissed.
+func buildChangesRequesters(nodes []*v4.Review) map[string]*User {
+	latest := make(map[string]*v4.Review)
+	for _, node := range nodes {
+		review := newReview(node)
+		login := review.Author.Login
+		if login == "" {
+			continue
+		}
+		if a, ok := latest[login]; ok && node.CreatedAt.Before(a.CreatedAt.Time) {
+			continue
+		}
+		latest[login] = node
+	}
+	requesters := make(map[string]*User)
+	for login, review := range latest {
+		if review.State == "CHANGES_REQUESTED" {
+			requesters[login] = newUser(review.Author)
+		}
+	}
+	return requesters
+}
@@ -0,0 +1,15 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// IsOrgMember reports whether the user is a member of the organization.
+func (c *Client) IsOrgMember(ctx context.Context, org, user string) (bool, error) {
+	isMember, err := c.v3Client.IsOrgMember(ctx, org, user)
+	if err != nil {
+		return false, fmt.Errorf("check the organization membership: %w", err)
+	}
+	return isMember, nil
+}
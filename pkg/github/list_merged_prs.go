@@ -0,0 +1,15 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListMergedPRs lists numbers of pull requests merged recently, up to limit.
+func (c *Client) ListMergedPRs(ctx context.Context, owner, repo string, limit int) ([]int, error) {
+	numbers, err := c.v3Client.ListMergedPRs(ctx, owner, repo, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list merged pull requests: %w", err)
+	}
+	return numbers, nil
+}
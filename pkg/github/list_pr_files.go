@@ -0,0 +1,15 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListPRFiles lists file paths changed in a pull request.
+func (c *Client) ListPRFiles(ctx context.Context, owner, repo string, number int) ([]string, error) {
+	files, err := c.v3Client.ListPRFiles(ctx, owner, repo, number)
+	if err != nil {
+		return nil, fmt.Errorf("list pull request files: %w", err)
+	}
+	return files, nil
+}
@@ -0,0 +1,15 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// ListTeamMembers lists logins of members of a team.
+func (c *Client) ListTeamMembers(ctx context.Context, org, team string) ([]string, error) {
+	members, err := c.v3Client.ListTeamMembers(ctx, org, team)
+	if err != nil {
+		return nil, fmt.Errorf("list team members: %w", err)
+	}
+	return members, nil
+}
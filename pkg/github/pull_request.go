@@ -3,9 +3,14 @@ package github
 type PullRequest struct {
 	HeadSHA           string                      `json:"sha"`
 	BaseSHA           string                      `json:"base_sha"`
+	BaseRef           string                      `json:"base_ref"`
 	Approvers         map[string]*User            `json:"approvers"`
 	ApproversByCommit map[string]map[string]*User `json:"approvers_by_commit"`
-	Commits           []*Commit                   `json:"commits"`
+	// ChangesRequesters are reviewers whose latest review requests changes
+	ChangesRequesters map[string]*User `json:"changes_requesters"`
+	Commits           []*Commit        `json:"commits"`
+	// Pushes are force-pushes to the head branch
+	Pushes []*Push `json:"pushes"`
 }
 
 type Author struct {
@@ -0,0 +1,42 @@
+package github
+
+import (
+	"time"
+
+	v4 "github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github/v4"
+)
+
+// Push is a push to the head branch of a pull request.
+type Push struct {
+	Pusher      *User     `json:"pusher"`
+	BeforeSHA   string    `json:"before_sha"`
+	AfterSHA    string    `json:"after_sha"`
+	PushedAt    time.Time `json:"pushed_at"`
+	ForcePushed bool      `json:"force_pushed"`
+}
+
+func newPushes(items *v4.TimelineItems) []*Push {
+	if items == nil {
+		return nil
+	}
+	var pushes []*Push
+	for _, item := range items.Nodes {
+		ev := item.HeadRefForcePushedEvent
+		if ev == nil {
+			continue
+		}
+		push := &Push{
+			Pusher:      newUser(ev.Actor),
+			PushedAt:    ev.CreatedAt.Time,
+			ForcePushed: true,
+		}
+		if ev.BeforeCommit != nil {
+			push.BeforeSHA = ev.BeforeCommit.OID
+		}
+		if ev.AfterCommit != nil {
+			push.AfterSHA = ev.AfterCommit.OID
+		}
+		pushes = append(pushes, push)
+	}
+	return pushes
+}
@@ -0,0 +1,23 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	v3 "github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github/v3"
+)
+
+// GetDefaultBranchFile gets the content of a file on the default branch.
+// It returns an empty string if the file doesn't exist.
+func (c *Client) GetDefaultBranchFile(ctx context.Context, owner, repo, path string) (string, error) {
+	// If ref is empty, the default branch is used.
+	content, err := c.v3Client.GetFileContent(ctx, owner, repo, path, "")
+	if err != nil {
+		if errors.Is(err, v3.ErrNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("get a file on the default branch: %w", err)
+	}
+	return content, nil
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// repoCacheTTL is how long repository metadata is cached.
+// Topics and custom properties rarely change, so the cache avoids an API call per webhook.
+const repoCacheTTL = 10 * time.Minute
+
+// Repository is metadata of a repository used to select repository configs.
+type Repository struct {
+	FullName string `json:"full_name"`
+	// NodeID is the GraphQL node ID used to create check runs
+	NodeID string `json:"node_id,omitempty"`
+	// Topics are lower-cased topics
+	Topics []string `json:"topics,omitempty"`
+	// Visibility is public, private, or internal
+	Visibility string `json:"visibility,omitempty"`
+	Archived   bool   `json:"archived,omitempty"`
+	Fork       bool   `json:"fork,omitempty"`
+	// CustomProperties are values per property name.
+	// A multi select property has multiple values.
+	CustomProperties map[string][]string `json:"custom_properties,omitempty"`
+}
+
+type repoCache struct {
+	mutex sync.Mutex
+	repos map[string]*cachedRepo
+}
+
+type cachedRepo struct {
+	repo      *Repository
+	expiresAt time.Time
+}
+
+func (c *repoCache) get(key string, now time.Time) *Repository {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	r, ok := c.repos[key]
+	if !ok || now.After(r.expiresAt) {
+		return nil
+	}
+	return r.repo
+}
+
+func (c *repoCache) set(key string, repo *Repository, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.repos[key] = &cachedRepo{
+		repo:      repo,
+		expiresAt: now.Add(repoCacheTTL),
+	}
+}
+
+// GetRepo gets metadata of a repository.
+// Results are cached for repoCacheTTL across requests.
+func (c *Client) GetRepo(ctx context.Context, owner, name string) (*Repository, error) {
+	key := strings.ToLower(owner + "/" + name)
+	now := time.Now()
+	if repo := c.repoCache.get(key, now); repo != nil {
+		return repo, nil
+	}
+	r, err := c.v3Client.GetRepo(ctx, owner, name)
+	if err != nil {
+		return nil, fmt.Errorf("get a repository: %w", err)
+	}
+	repo := newRepository(r)
+	c.repoCache.set(key, repo, now)
+	return repo, nil
+}
+
+func newRepository(r *github.Repository) *Repository {
+	repo := &Repository{
+		FullName:         r.GetFullName(),
+		NodeID:           r.GetNodeID(),
+		Topics:           make([]string, len(r.Topics)),
+		Visibility:       r.GetVisibility(),
+		Archived:         r.GetArchived(),
+		Fork:             r.GetFork(),
+		CustomProperties: make(map[string][]string, len(r.CustomProperties)),
+	}
+	for i, topic := range r.Topics {
+		repo.Topics[i] = strings.ToLower(topic)
+	}
+	for name, value := range r.CustomProperties {
+		// A value is a string, a list of strings for multi select properties, or null if it isn't set.
+		switch v := value.(type) {
+		case string:
+			repo.CustomProperties[name] = []string{v}
+		case []any:
+			for _, e := range v {
+				if s, ok := e.(string); ok {
+					repo.CustomProperties[name] = append(repo.CustomProperties[name], s)
+				}
+			}
+		}
+	}
+	return repo
+}
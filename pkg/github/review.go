@@ -20,4 +20,5 @@ type IgnoredApproval struct {
 	Login                  string
 	IsApp                  bool
 	IsUntrustedMachineUser bool
+	IsNotMember            bool
 }
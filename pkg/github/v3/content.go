@@ -0,0 +1,35 @@
+package v3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v90/github"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// GetFileContent gets the content of a file at the given ref.
+// It returns ErrNotFound if the file doesn't exist.
+func (c *Client) GetFileContent(ctx context.Context, owner, repo, path, ref string) (string, error) {
+	file, _, resp, err := c.client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{
+		Ref: ref,
+	})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("get a file content %s: %w", path, err)
+	}
+	if file == nil {
+		// The path is a directory
+		return "", ErrNotFound
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return "", fmt.Errorf("decode a file content %s: %w", path, err)
+	}
+	return content, nil
+}
@@ -0,0 +1,16 @@
+package v3
+
+import (
+	"context"
+	"fmt"
+)
+
+// IsOrgMember reports whether the user is a member of the organization.
+// The GitHub App requires the permission `Organization Members: Read-only`, otherwise only public members are visible.
+func (c *Client) IsOrgMember(ctx context.Context, org, user string) (bool, error) {
+	isMember, _, err := c.client.Organizations.IsMember(ctx, org, user)
+	if err != nil {
+		return false, fmt.Errorf("check if %s is a member of %s: %w", user, org, err)
+	}
+	return isMember, nil
+}
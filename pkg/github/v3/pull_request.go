@@ -0,0 +1,49 @@
+package v3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// ListPRFiles lists file paths changed in a pull request.
+// The previous paths of renamed files are also returned.
+func (c *Client) ListPRFiles(ctx context.Context, owner, repo string, number int) ([]string, error) {
+	var files []string
+	for file, err := range c.client.PullRequests.ListFilesIter(ctx, owner, repo, number, &github.ListOptions{PerPage: 100}) { //nolint:mnd
+		if err != nil {
+			return nil, fmt.Errorf("list pull request files: %w", err)
+		}
+		files = append(files, file.GetFilename())
+		if prev := file.GetPreviousFilename(); prev != "" {
+			files = append(files, prev)
+		}
+	}
+	return files, nil
+}
+
+// ListMergedPRs lists numbers of pull requests merged recently, up to limit.
+// Pull requests are sorted by the last update because GitHub API can't sort them by the merge time, so the result is approximate.
+func (c *Client) ListMergedPRs(ctx context.Context, owner, repo string, limit int) ([]int, error) {
+	var numbers []int
+	opts := &github.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: github.ListOptions{PerPage: 100}, //nolint:mnd
+	}
+	for pr, err := range c.client.PullRequests.ListIter(ctx, owner, repo, opts) {
+		if err != nil {
+			return nil, fmt.Errorf("list pull requests: %w", err)
+		}
+		if pr.MergedAt == nil {
+			continue
+		}
+		numbers = append(numbers, pr.GetNumber())
+		if len(numbers) >= limit {
+			break
+		}
+	}
+	return numbers, nil
+}
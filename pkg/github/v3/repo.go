@@ -0,0 +1,17 @@
+package v3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// GetRepo gets a repository including topics and custom properties.
+func (c *Client) GetRepo(ctx context.Context, owner, repo string) (*github.Repository, error) {
+	r, _, err := c.client.Repositories.Get(ctx, owner, repo)
+	if err != nil {
+		return nil, fmt.Errorf("get a repository %s/%s: %w", owner, repo, err)
+	}
+	return r, nil
+}
@@ -0,0 +1,22 @@
+package v3
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v90/github"
+)
+
+// ListTeamMembers lists logins of members of a team including members of child teams.
+func (c *Client) ListTeamMembers(ctx context.Context, org, team string) ([]string, error) {
+	var members []string
+	for user, err := range c.client.Teams.ListTeamMembersBySlugIter(ctx, org, team, &github.TeamListTeamMembersOptions{
+		ListOptions: github.ListOptions{PerPage: 100}, //nolint:mnd
+	}) {
+		if err != nil {
+			return nil, fmt.Errorf("list team members %s/%s: %w", org, team, err)
+		}
+		members = append(members, user.GetLogin())
+	}
+	return members, nil
+}
@@ -18,9 +18,9 @@ type Client struct {
 type PullRequestReviewEvent = github.PullRequestReviewEvent
 
 func New(param *ParamNewApp) (*Client, error) {
-	itr, err := ghinstallation.New(http.DefaultTransport, param.AppID, param.InstallationID, []byte(param.KeyFile))
+	itr, err := newTransport(param)
 	if err != nil {
-		return nil, fmt.Errorf("create a transport with private key: %w", err)
+		return nil, err
 	}
 	c := retryablehttp.NewClient()
 	c.HTTPClient = &http.Client{Transport: itr}
@@ -34,5 +34,30 @@ type ParamNewApp struct {
 	AppID          int64
 	KeyFile        string
 	InstallationID int64
-	Logger         *slog.Logger
+	// Token is an access token used instead of the GitHub App
+	Token  string
+	Logger *slog.Logger
+}
+
+func newTransport(param *ParamNewApp) (http.RoundTripper, error) {
+	if param.Token != "" {
+		return &tokenTransport{token: param.Token, base: http.DefaultTransport}, nil
+	}
+	itr, err := ghinstallation.New(http.DefaultTransport, param.AppID, param.InstallationID, []byte(param.KeyFile))
+	if err != nil {
+		return nil, fmt.Errorf("create a transport with private key: %w", err)
+	}
+	return itr, nil
+}
+
+// tokenTransport authenticates requests with the access token.
+type tokenTransport struct {
+	token string
+	base  http.RoundTripper
+}
+
+func (t *tokenTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+t.token)
+	return t.base.RoundTrip(req) //nolint:wrapcheck
 }
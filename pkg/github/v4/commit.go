@@ -8,11 +8,18 @@ type Commit struct {
 	OID                     string     `json:"oid"`
 	Committer               *Committer `json:"committer"`
 	Author                  *Committer `json:"author"`
+	Authors                 *GitActors `json:"authors" graphql:"authors(first:10)"`
 	Signature               *Signature `json:"signature"`
 	Parents                 *Parents   `json:"parents" graphql:"parents(first:10)"`
 	ChangedFilesIfAvailable *int       `json:"changedFilesIfAvailable"`
 }
 
+// GitActors are the author and co-authors of a commit.
+// GitHub resolves co-authors from Co-authored-by trailers of the commit message.
+type GitActors struct {
+	Nodes []*Committer `json:"nodes"`
+}
+
 type Parents struct {
 	Nodes []*ParentCommit `json:"nodes"`
 }
@@ -24,6 +31,59 @@ type ParentCommit struct {
 type Signature struct {
 	IsValid bool   `json:"isValid"`
 	State   string `json:"state"`
+	// WasSignedByGitHub is true if GitHub signed the commit, for example commits created on the web UI or by GitHub Apps via API.
+	WasSignedByGitHub bool `json:"wasSignedByGitHub"`
+	// Payload is the raw commit object without the signature header.
+	Payload string `json:"payload"`
+	// Signature is the armored signature.
+	Signature    string       `json:"signature"`
+	Typename     string       `json:"__typename" graphql:"__typename"`
+	GpgSignature GpgSignature `json:"gpgSignature" graphql:"... on GpgSignature"`
+	SSHSignature SSHSignature `json:"sshSignature" graphql:"... on SshSignature"`
+}
+
+type GpgSignature struct {
+	KeyID string `json:"keyId"`
+}
+
+type SSHSignature struct {
+	KeyFingerprint string `json:"keyFingerprint"`
+}
+
+// Signature types
+const (
+	SignatureTypeGPG     = "gpg"
+	SignatureTypeSSH     = "ssh"
+	SignatureTypeX509    = "x509"
+	SignatureTypeUnknown = "unknown"
+)
+
+// Type returns the signature type such as gpg, ssh, and x509.
+// S/MIME and gitsign signatures are x509 because GitHub doesn't distinguish them.
+func (s *Signature) Type() string {
+	switch s.Typename {
+	case "GpgSignature":
+		return SignatureTypeGPG
+	case "SshSignature":
+		return SignatureTypeSSH
+	case "SmimeSignature":
+		return SignatureTypeX509
+	default:
+		return SignatureTypeUnknown
+	}
+}
+
+// Key returns the GPG key ID or the SSH key fingerprint.
+// It returns an empty string for other signature types.
+func (s *Signature) Key() string {
+	switch s.Typename {
+	case "GpgSignature":
+		return s.GpgSignature.KeyID
+	case "SshSignature":
+		return s.SSHSignature.KeyFingerprint
+	default:
+		return ""
+	}
 }
 
 func (c *Commit) User() *User {
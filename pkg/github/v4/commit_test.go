@@ -0,0 +1,57 @@
+package v4_test
+
+import (
+	"testing"
+
+	v4 "github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github/v4"
+)
+
+func TestSignature_TypeAndKey(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		sig      *v4.Signature
+		wantType string
+		wantKey  string
+	}{
+		{
+			name: "gpg",
+			sig: &v4.Signature{
+				Typename:     "GpgSignature",
+				GpgSignature: v4.GpgSignature{KeyID: "4AEE18F83AFDEB23"},
+			},
+			wantType: "gpg",
+			wantKey:  "4AEE18F83AFDEB23",
+		},
+		{
+			name: "ssh",
+			sig: &v4.Signature{
+				Typename:     "SshSignature",
+				SSHSignature: v4.SSHSignature{KeyFingerprint: "SHA256:abc"},
+			},
+			wantType: "ssh",
+			wantKey:  "SHA256:abc",
+		},
+		{
+			name:     "smime",
+			sig:      &v4.Signature{Typename: "SmimeSignature"},
+			wantType: "x509",
+		},
+		{
+			name:     "unknown",
+			sig:      &v4.Signature{Typename: "UnknownSignature"},
+			wantType: "unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.sig.Type(); got != tt.wantType {
+				t.Errorf("Type() = %q, want %q", got, tt.wantType)
+			}
+			if got := tt.sig.Key(); got != tt.wantKey {
+				t.Errorf("Key() = %q, want %q", got, tt.wantKey)
+			}
+		})
+	}
+}
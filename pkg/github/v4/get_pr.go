@@ -2,6 +2,7 @@ package v4
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/shurcooL/githubv4"
@@ -38,9 +39,43 @@ func (c *Client) GetPR(ctx context.Context, owner, name string, number int) (*Pu
 			q.Repository.PullRequest.Commits.Nodes = append(q.Repository.PullRequest.Commits.Nodes, commits...)
 		}
 	}
+	if items := q.Repository.PullRequest.TimelineItems; items != nil && items.PageInfo != nil && items.PageInfo.HasPreviousPage {
+		// Missing force-pushes could hide self-approvals, so all of them are fetched
+		older, err := c.ListForcePushes(ctx, owner, name, number, items.PageInfo.StartCursor)
+		if err != nil {
+			return nil, fmt.Errorf("list force-pushes by GitHub GraphQL API: %w", err)
+		}
+		items.Nodes = append(older, items.Nodes...)
+	}
 	return q.Repository.PullRequest, nil
 }
 
+// ListForcePushes lists force-push events of a pull request before the cursor via GitHub GraphQL API.
+// Events are returned in chronological order.
+// It returns an error if there are too many events to fetch, because ignoring some of them could hide self-approvals.
+func (c *Client) ListForcePushes(ctx context.Context, owner, name string, number int, cursor string) ([]*TimelineItem, error) {
+	var items []*TimelineItem
+	variables := map[string]any{
+		keyRepoOwner: githubv4.String(owner),
+		keyRepoName:  githubv4.String(name),
+		keyNumber:    githubv4.Int(number), //nolint:gosec
+		"cursor":     githubv4.String(cursor),
+	}
+	for range 100 {
+		q := &ListForcePushesQuery{}
+		if err := c.v4Client.Query(ctx, q, variables); err != nil {
+			return nil, fmt.Errorf("list force-pushes by GitHub GraphQL API: %w", err)
+		}
+		items = append(q.Nodes(), items...)
+		pageInfo := q.PageInfo()
+		if pageInfo == nil || !pageInfo.HasPreviousPage {
+			return items, nil
+		}
+		variables["cursor"] = githubv4.String(pageInfo.StartCursor)
+	}
+	return nil, errors.New("the pull request has too many force-pushes")
+}
+
 // ListReviews lists reviews of a pull request via GitHub GraphQL API.
 func (c *Client) ListReviews(ctx context.Context, owner, name string, number int, cursor string) ([]*Review, error) {
 	var reviews []*Review
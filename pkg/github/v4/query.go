@@ -5,6 +5,7 @@ query($owner: String!, $repo: String!, $pr: Int!) {
   repository(owner: $owner, name: $repo) {
     pullRequest(number: $pr) {
       headRefOid
+      baseRefName
       reviews(first: 100) {
         pageInfo {
           hasNextPage
@@ -21,6 +22,27 @@ query($owner: String!, $repo: String!, $pr: Int!) {
           }
         }
       }
+      timelineItems(last: 30, itemTypes: [HEAD_REF_FORCE_PUSHED_EVENT]) {
+        pageInfo {
+          hasPreviousPage
+          startCursor
+        }
+        nodes {
+          ... on HeadRefForcePushedEvent {
+            actor {
+              login
+              resourcePath
+            }
+            beforeCommit {
+              oid
+            }
+            afterCommit {
+              oid
+            }
+            createdAt
+          }
+        }
+      }
       commits(first: 100) {
         pageInfo {
           hasNextPage
@@ -40,10 +62,28 @@ query($owner: String!, $repo: String!, $pr: Int!) {
                 login
                 resourcePath
               }
+            }
+            authors(first: 10) {
+              nodes {
+                user {
+                  login
+                  resourcePath
+                }
+              }
             }
 			signature {
+			  __typename
 			  isValid
 			  state
+			  wasSignedByGitHub
+			  payload
+			  signature
+			  ... on GpgSignature {
+			    keyId
+			  }
+			  ... on SshSignature {
+			    keyFingerprint
+			  }
 			}
           }
         }
@@ -56,10 +96,14 @@ query($owner: String!, $repo: String!, $pr: Int!) {
 type PullRequest struct {
 	HeadRefOID string `json:"headRefOid"`
 	BaseRefOID string `json:"baseRefOid"`
+	// BaseRefName is the base branch name such as main
+	BaseRefName string `json:"baseRefName"`
 	// latestReviews isn't appropriate.
 	// If someone adds a review comment after approval, the last review is the comment, not the approval.
 	Reviews *Reviews `json:"reviews" graphql:"reviews(first:30, states: [APPROVED, DISMISSED, CHANGES_REQUESTED])"`
 	Commits *Commits `json:"commits" graphql:"commits(first:30)"`
+	// Force-pushes are fetched from the latest. Older ones are fetched by ListForcePushes.
+	TimelineItems *TimelineItems `json:"timelineItems" graphql:"timelineItems(last:30, itemTypes: [HEAD_REF_FORCE_PUSHED_EVENT])"`
 }
 
 type Author struct {
@@ -139,3 +139,24 @@ func TestCommitter_Login(t *testing.T) {
 		t.Errorf("Committer.Login() = %v, want %v", got, want)
 	}
 }
+
+func TestListForcePushesQuery_PageInfo(t *testing.T) {
+	t.Parallel()
+	want := &v4.TimelinePageInfo{
+		HasPreviousPage: true,
+		StartCursor:     "cursor123",
+	}
+	query := &v4.ListForcePushesQuery{
+		Repository: &v4.TimelineRepository{
+			PullRequest: &v4.TimelinePullRequest{
+				TimelineItems: &v4.TimelineItems{
+					PageInfo: want,
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, query.PageInfo()); diff != "" {
+		t.Errorf("ListForcePushesQuery.PageInfo() mismatch (-want +got):\n%s", diff)
+	}
+}
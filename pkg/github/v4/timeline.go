@@ -0,0 +1,51 @@
+package v4
+
+import "github.com/shurcooL/githubv4"
+
+// TimelineItems are timeline items of a pull request.
+// Only HeadRefForcePushedEvent is fetched because GitHub GraphQL API doesn't expose who pushed commits without force-push.
+// Items are fetched from the latest, so older items are fetched with PageInfo.StartCursor.
+type TimelineItems struct {
+	PageInfo *TimelinePageInfo `json:"pageInfo"`
+	Nodes    []*TimelineItem   `json:"nodes"`
+}
+
+type TimelinePageInfo struct {
+	HasPreviousPage bool   `json:"hasPreviousPage"`
+	StartCursor     string `json:"startCursor"`
+}
+
+type ListForcePushesQuery struct {
+	Repository *TimelineRepository `graphql:"repository(owner: $repoOwner, name: $repoName)"`
+}
+
+func (q *ListForcePushesQuery) PageInfo() *TimelinePageInfo {
+	return q.Repository.PullRequest.TimelineItems.PageInfo
+}
+
+func (q *ListForcePushesQuery) Nodes() []*TimelineItem {
+	return q.Repository.PullRequest.TimelineItems.Nodes
+}
+
+type TimelineRepository struct {
+	PullRequest *TimelinePullRequest `graphql:"pullRequest(number: $number)"`
+}
+
+type TimelinePullRequest struct {
+	TimelineItems *TimelineItems `graphql:"timelineItems(last:30, before:$cursor, itemTypes: [HEAD_REF_FORCE_PUSHED_EVENT])"`
+}
+
+type TimelineItem struct {
+	HeadRefForcePushedEvent *HeadRefForcePushedEvent `json:"headRefForcePushedEvent,omitempty" graphql:"... on HeadRefForcePushedEvent"`
+}
+
+type HeadRefForcePushedEvent struct {
+	Actor        *User             `json:"actor"`
+	BeforeCommit *PushedCommit     `json:"beforeCommit"`
+	AfterCommit  *PushedCommit     `json:"afterCommit"`
+	CreatedAt    githubv4.DateTime `json:"createdAt"`
+}
+
+type PushedCommit struct {
+	OID string `json:"oid"`
+}
@@ -0,0 +1,147 @@
+// Package gitsign verifies keyless commit signatures created by gitsign.
+// Certificates issued by Fulcio are verified offline with configured trust roots.
+// The Rekor transparency log isn't checked.
+package gitsign
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+)
+
+var (
+	ErrUnsigned           = errors.New("the commit isn't signed")
+	ErrNoIdentity         = errors.New("no identity is configured")
+	ErrIdentityMismatch   = errors.New("the certificate identity isn't allowed")
+	ErrUnsupportedSigType = errors.New("the signature isn't a gitsign signature")
+)
+
+// Fulcio certificate extensions of the OIDC issuer.
+// https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+var (
+	oidIssuer   = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1} //nolint:gochecknoglobals
+	oidIssuerV2 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8} //nolint:gochecknoglobals
+)
+
+// Identity is a certificate identity.
+// Subject is an email address or a URI in the Subject Alternative Name.
+// Issuer is the OIDC issuer such as https://github.com/login/oauth.
+type Identity struct {
+	Subject string
+	Issuer  string
+}
+
+// Verifier verifies gitsign signatures with Fulcio trust roots and identities per lower-cased login.
+type Verifier struct {
+	roots         *x509.CertPool
+	intermediates *x509.CertPool
+	identities    map[string][]*Identity
+}
+
+// New parses PEM encoded root and intermediate certificates.
+func New(roots, intermediates string, identities map[string][]*Identity) (*Verifier, error) {
+	v := &Verifier{
+		roots:         x509.NewCertPool(),
+		intermediates: x509.NewCertPool(),
+		identities:    make(map[string][]*Identity, len(identities)),
+	}
+	if !v.roots.AppendCertsFromPEM([]byte(roots)) {
+		return nil, errors.New("no root certificate is found")
+	}
+	if intermediates != "" && !v.intermediates.AppendCertsFromPEM([]byte(intermediates)) {
+		return nil, errors.New("no intermediate certificate is found")
+	}
+	for login, ids := range identities {
+		login = strings.ToLower(login)
+		v.identities[login] = append(v.identities[login], ids...)
+	}
+	return v, nil
+}
+
+// Verify verifies a PEM encoded CMS signature over payload and the certificate identity of the login.
+// The certificate chain is verified at the signing time because Fulcio certificates expire in minutes.
+func (v *Verifier) Verify(login, payload, signature string) error {
+	if signature == "" {
+		return ErrUnsigned
+	}
+	block, _ := pem.Decode([]byte(signature))
+	if block == nil || block.Type != "SIGNED MESSAGE" {
+		return ErrUnsupportedSigType
+	}
+	ids, ok := v.identities[strings.ToLower(login)]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoIdentity, login)
+	}
+	p7, err := pkcs7.Parse(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("parse the signature: %w", err)
+	}
+	p7.Content = []byte(payload)
+	cert := p7.GetOnlySigner()
+	if cert == nil {
+		return errors.New("the signature must have only one signer")
+	}
+	var signingTime time.Time
+	if err := p7.UnmarshalSignedAttribute(pkcs7.OIDAttributeSigningTime, &signingTime); err != nil {
+		return fmt.Errorf("get the signing time: %w", err)
+	}
+	// The chain isn't verified here because configured intermediates must be used.
+	if err := p7.VerifyWithChainAtTime(nil, signingTime); err != nil {
+		return fmt.Errorf("verify the signature: %w", err)
+	}
+	intermediates := v.intermediates.Clone()
+	for _, c := range p7.Certificates {
+		intermediates.AddCert(c)
+	}
+	if _, err := cert.Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		CurrentTime:   signingTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return fmt.Errorf("verify the certificate chain: %w", err)
+	}
+	return matchIdentity(cert, ids)
+}
+
+func matchIdentity(cert *x509.Certificate, ids []*Identity) error {
+	issuer, err := getIssuer(cert)
+	if err != nil {
+		return err
+	}
+	subjects := slices.Clone(cert.EmailAddresses)
+	for _, u := range cert.URIs {
+		subjects = append(subjects, u.String())
+	}
+	for _, id := range ids {
+		if id.Issuer == issuer && slices.Contains(subjects, id.Subject) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: subject %s, issuer %s", ErrIdentityMismatch, strings.Join(subjects, ", "), issuer)
+}
+
+func getIssuer(cert *x509.Certificate) (string, error) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuerV2) {
+			var issuer string
+			if _, err := asn1.Unmarshal(ext.Value, &issuer); err != nil {
+				return "", fmt.Errorf("parse the OIDC issuer of the certificate: %w", err)
+			}
+			return issuer, nil
+		}
+	}
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(oidIssuer) {
+			return string(ext.Value), nil
+		}
+	}
+	return "", errors.New("the certificate has no OIDC issuer")
+}
@@ -0,0 +1,193 @@
+package gitsign_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/gitsign"
+	"go.mozilla.org/pkcs7"
+)
+
+const (
+	payload = "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\ntest\n"
+	issuer  = "https://github.com/login/oauth"
+)
+
+type ca struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newCA(t *testing.T) *ca {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sigstore"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ca{cert: cert, key: key}
+}
+
+func (c *ca) pem() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}))
+}
+
+// sign creates a signature like gitsign with a certificate issued for email.
+func (c *ca) sign(t *testing.T, email, message string) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuerExt, err := asn1.MarshalWithParams(issuer, "utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       time.Now().Add(-time.Minute),
+		NotAfter:        time.Now().Add(10 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		EmailAddresses:  []string{email},
+		ExtraExtensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}, Value: issuerExt}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, c.cert, key.Public(), c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd, err := pkcs7.NewSignedData([]byte(message))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+	if err := sd.AddSigner(cert, key, pkcs7.SignerInfoConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	sd.Detach()
+	sig, err := sd.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "SIGNED MESSAGE", Bytes: sig}))
+}
+
+func TestVerifier_Verify(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	root := newCA(t)
+	other := newCA(t)
+	v, err := gitsign.New(root.pem(), "", map[string][]*gitsign.Identity{
+		"Octocat": {{Subject: "octocat@example.com", Issuer: issuer}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		login     string
+		payload   string
+		signature string
+		wantErr   error
+		isErr     bool
+	}{
+		{
+			name:      "valid",
+			login:     "octocat",
+			payload:   payload,
+			signature: root.sign(t, "octocat@example.com", payload),
+		},
+		{
+			name:      "identity mismatch",
+			login:     "octocat",
+			payload:   payload,
+			signature: root.sign(t, "mallory@example.com", payload),
+			wantErr:   gitsign.ErrIdentityMismatch,
+		},
+		{
+			name:      "untrusted root",
+			login:     "octocat",
+			payload:   payload,
+			signature: other.sign(t, "octocat@example.com", payload),
+			isErr:     true,
+		},
+		{
+			name:      "tampered payload",
+			login:     "octocat",
+			payload:   payload + "tampered",
+			signature: root.sign(t, "octocat@example.com", payload),
+			isErr:     true,
+		},
+		{
+			name:      "no identity",
+			login:     "foo",
+			payload:   payload,
+			signature: root.sign(t, "octocat@example.com", payload),
+			wantErr:   gitsign.ErrNoIdentity,
+		},
+		{
+			name:      "GPG signature",
+			login:     "octocat",
+			payload:   payload,
+			signature: "-----BEGIN PGP SIGNATURE-----\n-----END PGP SIGNATURE-----\n",
+			wantErr:   gitsign.ErrUnsupportedSigType,
+		},
+		{
+			name:    "unsigned",
+			login:   "octocat",
+			payload: payload,
+			wantErr: gitsign.ErrUnsigned,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := v.Verify(tt.login, tt.payload, tt.signature)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if (err != nil) != tt.isErr {
+				t.Fatalf("Verify() error = %v, isErr %v", err, tt.isErr)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	if _, err := gitsign.New("invalid", "", nil); err == nil {
+		t.Error("New() should return an error if no root certificate is found")
+	}
+}
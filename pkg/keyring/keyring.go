@@ -0,0 +1,110 @@
+// Package keyring verifies commit signatures with public keys configured per GitHub user.
+// Unlike GitHub's signature verification, keys registered to GitHub accounts aren't trusted.
+package keyring
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	ErrUnsigned           = errors.New("the commit isn't signed")
+	ErrNoKey              = errors.New("no key is registered in the keyring")
+	ErrUnsupportedSigType = errors.New("the signature type isn't supported")
+)
+
+// Keyring is GPG public keys and SSH public keys per lower-cased login.
+type Keyring struct {
+	gpg map[string]openpgp.EntityList
+	ssh map[string][]ssh.PublicKey
+}
+
+// New parses armored GPG public keys and SSH allowed_signers entries per login.
+func New(gpgKeys, sshKeys map[string][]string) (*Keyring, error) {
+	k := &Keyring{
+		gpg: make(map[string]openpgp.EntityList, len(gpgKeys)),
+		ssh: make(map[string][]ssh.PublicKey, len(sshKeys)),
+	}
+	for login, keys := range gpgKeys {
+		login = strings.ToLower(login)
+		for _, key := range keys {
+			entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+			if err != nil {
+				return nil, fmt.Errorf("read a GPG public key of %s: %w", login, err)
+			}
+			k.gpg[login] = append(k.gpg[login], entities...)
+		}
+	}
+	for login, entries := range sshKeys {
+		login = strings.ToLower(login)
+		for _, entry := range entries {
+			key, err := parseAllowedSigner(entry)
+			if err != nil {
+				return nil, fmt.Errorf("parse an SSH allowed signer of %s: %w", login, err)
+			}
+			k.ssh[login] = append(k.ssh[login], key)
+		}
+	}
+	return k, nil
+}
+
+// parseAllowedSigner parses an allowed_signers entry.
+// The principals field is optional because keys are mapped to logins.
+// Options such as namespaces are ignored.
+func parseAllowedSigner(entry string) (ssh.PublicKey, error) {
+	entry = strings.TrimSpace(entry)
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(entry))
+	if err == nil {
+		return key, nil
+	}
+	_, rest, ok := strings.Cut(entry, " ")
+	if !ok {
+		return nil, fmt.Errorf("parse a public key: %w", err)
+	}
+	key, _, _, _, err = ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(rest)))
+	if err != nil {
+		return nil, fmt.Errorf("parse a public key: %w", err)
+	}
+	return key, nil
+}
+
+// Has returns true if any key is registered for the login.
+func (k *Keyring) Has(login string) bool {
+	login = strings.ToLower(login)
+	return len(k.gpg[login]) > 0 || len(k.ssh[login]) > 0
+}
+
+// Verify verifies an armored signature over payload with keys of the login.
+// payload is the raw commit object without the signature header.
+func (k *Keyring) Verify(login, payload, signature string) error {
+	if signature == "" {
+		return ErrUnsigned
+	}
+	login = strings.ToLower(login)
+	switch {
+	case strings.HasPrefix(signature, "-----BEGIN PGP SIGNATURE-----"):
+		entities, ok := k.gpg[login]
+		if !ok {
+			return fmt.Errorf("%w: GPG key of %s", ErrNoKey, login)
+		}
+		if _, err := openpgp.CheckArmoredDetachedSignature(entities, strings.NewReader(payload), strings.NewReader(signature), nil); err != nil {
+			return fmt.Errorf("verify a GPG signature: %w", err)
+		}
+		return nil
+	case strings.HasPrefix(signature, sshSigArmorStart):
+		keys, ok := k.ssh[login]
+		if !ok {
+			return fmt.Errorf("%w: SSH key of %s", ErrNoKey, login)
+		}
+		if err := verifySSHSignature(keys, []byte(payload), signature); err != nil {
+			return fmt.Errorf("verify an SSH signature: %w", err)
+		}
+		return nil
+	default:
+		return ErrUnsupportedSigType
+	}
+}
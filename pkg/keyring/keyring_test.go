@@ -0,0 +1,242 @@
+package keyring_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/keyring"
+	"golang.org/x/crypto/ssh"
+)
+
+const payload = `tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904
+author octocat <octocat@example.com> 1700000000 +0900
+committer octocat <octocat@example.com> 1700000000 +0900
+
+test
+`
+
+func newGPGKey(t *testing.T) (*openpgp.Entity, string) {
+	t.Helper()
+	entity, err := openpgp.NewEntity("octocat", "", "octocat@example.com", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := entity.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return entity, buf.String()
+}
+
+func signGPG(t *testing.T, entity *openpgp.Entity, message string) string {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	if err := openpgp.ArmoredDetachSign(buf, entity, strings.NewReader(message), nil); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func newSSHKey(t *testing.T) (ssh.Signer, string) {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer, strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+}
+
+// signSSH creates a signature like `ssh-keygen -Y sign -n git`.
+func signSSH(t *testing.T, signer ssh.Signer, namespace, message string) string {
+	t.Helper()
+	h := sha512.Sum512([]byte(message))
+	signed := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          []byte
+	}{namespace, "", "sha512", h[:]})...)
+	sig, err := signer.Sign(rand.Reader, signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     []byte
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     []byte
+	}{1, signer.PublicKey().Marshal(), namespace, "", "sha512", ssh.Marshal(sig)})...)
+	return "-----BEGIN SSH SIGNATURE-----\n" + base64.StdEncoding.EncodeToString(blob) + "\n-----END SSH SIGNATURE-----\n"
+}
+
+func TestKeyring_Verify(t *testing.T) { //nolint:funlen
+	t.Parallel()
+	gpgEntity, gpgPub := newGPGKey(t)
+	otherGPGEntity, _ := newGPGKey(t)
+	sshSigner, sshPub := newSSHKey(t)
+	otherSSHSigner, _ := newSSHKey(t)
+
+	kr, err := keyring.New(map[string][]string{
+		"Octocat": {gpgPub},
+	}, map[string][]string{
+		"octocat": {"octocat@example.com " + sshPub},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		login     string
+		payload   string
+		signature string
+		wantErr   error
+		isErr     bool
+	}{
+		{
+			name:      "valid GPG signature",
+			login:     "octocat",
+			payload:   payload,
+			signature: signGPG(t, gpgEntity, payload),
+		},
+		{
+			name:      "GPG signature by an unregistered key",
+			login:     "octocat",
+			payload:   payload,
+			signature: signGPG(t, otherGPGEntity, payload),
+			isErr:     true,
+		},
+		{
+			name:      "tampered payload of a GPG signature",
+			login:     "octocat",
+			payload:   payload + "tampered",
+			signature: signGPG(t, gpgEntity, payload),
+			isErr:     true,
+		},
+		{
+			name:      "GPG signature of a user without keys",
+			login:     "foo",
+			payload:   payload,
+			signature: signGPG(t, gpgEntity, payload),
+			wantErr:   keyring.ErrNoKey,
+		},
+		{
+			name:      "valid SSH signature",
+			login:     "OCTOCAT",
+			payload:   payload,
+			signature: signSSH(t, sshSigner, "git", payload),
+		},
+		{
+			name:      "SSH signature by an unregistered key",
+			login:     "octocat",
+			payload:   payload,
+			signature: signSSH(t, otherSSHSigner, "git", payload),
+			isErr:     true,
+		},
+		{
+			name:      "SSH signature with another namespace",
+			login:     "octocat",
+			payload:   payload,
+			signature: signSSH(t, sshSigner, "file", payload),
+			isErr:     true,
+		},
+		{
+			name:      "tampered payload of an SSH signature",
+			login:     "octocat",
+			payload:   payload + "tampered",
+			signature: signSSH(t, sshSigner, "git", payload),
+			isErr:     true,
+		},
+		{
+			name:    "unsigned",
+			login:   "octocat",
+			payload: payload,
+			wantErr: keyring.ErrUnsigned,
+		},
+		{
+			name:      "x509 signature",
+			login:     "octocat",
+			payload:   payload,
+			signature: "-----BEGIN SIGNED MESSAGE-----\n-----END SIGNED MESSAGE-----\n",
+			wantErr:   keyring.ErrUnsupportedSigType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := kr.Verify(tt.login, tt.payload, tt.signature)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if (err != nil) != tt.isErr {
+				t.Fatalf("Verify() error = %v, isErr %v", err, tt.isErr)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	_, sshPub := newSSHKey(t)
+	tests := []struct {
+		name    string
+		gpg     map[string][]string
+		ssh     map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "allowed_signers entry without principals",
+			ssh:  map[string][]string{"octocat": {sshPub}},
+		},
+		{
+			name: "allowed_signers entry with options",
+			ssh:  map[string][]string{"octocat": {`octocat@example.com namespaces="git" ` + sshPub}},
+		},
+		{
+			name:    "invalid SSH key",
+			ssh:     map[string][]string{"octocat": {"ssh-ed25519 invalid"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid GPG key",
+			gpg:     map[string][]string{"octocat": {"invalid"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			kr, err := keyring.New(tt.gpg, tt.ssh)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !kr.Has("octocat") {
+				t.Error("Has() = false, want true")
+			}
+		})
+	}
+}
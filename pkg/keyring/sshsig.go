@@ -0,0 +1,121 @@
+package keyring
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSH signatures follow the format of OpenSSH.
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig
+const (
+	sshSigArmorStart = "-----BEGIN SSH SIGNATURE-----"
+	sshSigArmorEnd   = "-----END SSH SIGNATURE-----"
+	sshSigMagic      = "SSHSIG"
+	sshSigVersion    = 1
+	// git signs commits with the namespace "git".
+	sshSigNamespace = "git"
+)
+
+var errSSHKeyNotAllowed = errors.New("the signing key isn't registered in the keyring")
+
+type sshSig struct {
+	Version       uint32
+	PublicKey     []byte
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Signature     []byte
+}
+
+type sshSignedData struct {
+	Namespace     string
+	Reserved      string
+	HashAlgorithm string
+	Hash          []byte
+}
+
+func verifySSHSignature(keys []ssh.PublicKey, message []byte, armored string) error {
+	blob, err := decodeSSHSig(armored)
+	if err != nil {
+		return err
+	}
+	sig := &sshSig{}
+	if err := ssh.Unmarshal(blob, sig); err != nil {
+		return fmt.Errorf("unmarshal the signature: %w", err)
+	}
+	if sig.Version != sshSigVersion {
+		return fmt.Errorf("unsupported signature version: %d", sig.Version)
+	}
+	if sig.Namespace != sshSigNamespace {
+		return fmt.Errorf("unexpected signature namespace: %q", sig.Namespace)
+	}
+	pub, err := ssh.ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return fmt.Errorf("parse the public key in the signature: %w", err)
+	}
+	if !containsKey(keys, pub) {
+		return fmt.Errorf("%w: %s", errSSHKeyNotAllowed, ssh.FingerprintSHA256(pub))
+	}
+	var h hash.Hash
+	switch sig.HashAlgorithm {
+	case "sha256":
+		h = sha256.New()
+	case "sha512":
+		h = sha512.New()
+	default:
+		return fmt.Errorf("unsupported hash algorithm: %q", sig.HashAlgorithm)
+	}
+	h.Write(message)
+	signed := append([]byte(sshSigMagic), ssh.Marshal(&sshSignedData{
+		Namespace:     sig.Namespace,
+		Reserved:      sig.Reserved,
+		HashAlgorithm: sig.HashAlgorithm,
+		Hash:          h.Sum(nil),
+	})...)
+	s := &ssh.Signature{}
+	if err := ssh.Unmarshal(sig.Signature, s); err != nil {
+		return fmt.Errorf("unmarshal the signature blob: %w", err)
+	}
+	if err := pub.Verify(signed, s); err != nil {
+		return fmt.Errorf("verify the signature: %w", err)
+	}
+	return nil
+}
+
+func decodeSSHSig(armored string) ([]byte, error) {
+	body, ok := strings.CutPrefix(strings.TrimSpace(armored), sshSigArmorStart)
+	if !ok {
+		return nil, errors.New("the signature isn't armored")
+	}
+	body, ok = strings.CutSuffix(body, sshSigArmorEnd)
+	if !ok {
+		return nil, errors.New("the signature isn't armored")
+	}
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return nil, fmt.Errorf("decode the signature: %w", err)
+	}
+	blob, ok = bytes.CutPrefix(blob, []byte(sshSigMagic))
+	if !ok {
+		return nil, errors.New("the signature doesn't start with SSHSIG")
+	}
+	return blob, nil
+}
+
+func containsKey(keys []ssh.PublicKey, key ssh.PublicKey) bool {
+	b := key.Marshal()
+	for _, k := range keys {
+		if bytes.Equal(k.Marshal(), b) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/suzuki-shunsuke/slog-error/slogerr"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/logging"
+)
+
+// configPollInterval is the interval to check if CONFIG_FILE is changed.
+// Polling works with Kubernetes ConfigMaps, which are updated by swapping symbolic links.
+const configPollInterval = 10 * time.Second
+
+// watchConfig reloads the config on SIGHUP and when CONFIG_FILE is changed until ctx is canceled.
+func (h *Server) watchConfig(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var poll <-chan time.Time
+	if interval := h.pollInterval(); interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		poll = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			h.reload(ctx, "SIGHUP")
+		case <-poll:
+			h.reload(ctx, "")
+		}
+	}
+}
+
+// pollInterval returns the interval to check if the config is changed.
+// If the config isn't read from a file or object storage, it returns 0.
+func (h *Server) pollInterval() time.Duration {
+	switch {
+	case h.source.IsObject():
+		return config.ObjectPollInterval
+	case h.source.IsFile():
+		return configPollInterval
+	default:
+		return 0
+	}
+}
+
+// reload reads the config and replaces the active config if it's changed.
+// trigger is logged. If it's empty, the config is reloaded quietly unless it's changed.
+func (h *Server) reload(ctx context.Context, trigger string) {
+	logger := h.logger
+	if trigger != "" {
+		logger = logger.With("trigger", trigger)
+	}
+	old := h.config.Load()
+	cfg, err := h.source.Reload(ctx, old)
+	if err != nil {
+		slogerr.WithError(logger, err).Error("failed to reload the config. The current config is kept", "config_hash", old.Hash)
+		return
+	}
+	if cfg == nil {
+		if trigger != "" {
+			logger.Info("the config isn't changed", "config_hash", old.Hash)
+		}
+		return
+	}
+	h.config.Store(cfg)
+	h.controller.SetConfig(cfg)
+	logger.Info("reloaded the config", "config_hash", cfg.Hash, "old_config_hash", old.Hash)
+	if err := logging.SetLevel(h.logLevel, cfg.LogLevel); err != nil {
+		slogerr.WithError(logger, err).Error("set the log level")
+	}
+}
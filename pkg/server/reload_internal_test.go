@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/controller"
+)
+
+type mockController struct {
+	config *config.Config
+}
+
+func (m *mockController) Run(_ context.Context, _ *slog.Logger, _ *controller.Request) error {
+	return nil
+}
+
+func (m *mockController) SetConfig(cfg *config.Config) {
+	m.config = cfg
+}
+
+const baseConfig = `
+app_id: 1
+installation_id: 2
+`
+
+func TestServer_reload(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		config      string
+		wantChanged bool
+	}{
+		{
+			name:   "not changed",
+			config: baseConfig,
+		},
+		{
+			name:        "changed",
+			config:      baseConfig + "required_approvals: 2\n",
+			wantChanged: true,
+		},
+		{
+			name:   "invalid config",
+			config: baseConfig + "required_approvals: -1\n",
+		},
+		{
+			name:   "app_id can't be changed",
+			config: "app_id: 3\ninstallation_id: 2\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &config.Config{}
+			if err := config.Parse(cfg, []byte(baseConfig)); err != nil {
+				t.Fatal(err)
+			}
+			ctrl := &mockController{}
+			logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+			getEnv := func(k string) string {
+				if k == "CONFIG" {
+					return tt.config
+				}
+				return ""
+			}
+			s, err := New(logger, &slog.LevelVar{}, ctrl, cfg, config.NewSource(nil, nil, getEnv))
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.reload(t.Context(), "SIGHUP")
+			active := s.config.Load()
+			if tt.wantChanged {
+				if active.Hash != config.Hash([]byte(tt.config)) {
+					t.Errorf("the config isn't reloaded: hash = %s", active.Hash)
+				}
+				if ctrl.config != active {
+					t.Error("the config of the controller isn't replaced")
+				}
+				return
+			}
+			if active != cfg || ctrl.config != nil {
+				t.Error("the config must not be replaced")
+			}
+		})
+	}
+}
@@ -21,7 +21,7 @@ const (
 func (h *Server) Start(ctx context.Context) {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/webhook", h.Run)
-	mux.HandleFunc("/ready", ready)
+	mux.HandleFunc("/ready", h.ready)
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = defaultPort
@@ -39,6 +39,9 @@ func (h *Server) Start(ctx context.Context) {
 		}
 	}()
 
+	h.logger.Info("started the server", "config_hash", h.config.Load().Hash)
+	go h.watchConfig(ctx)
+
 	<-ctx.Done()
 
 	h.logger.Info("shutting down gracefully...")
@@ -51,9 +54,10 @@ func (h *Server) Start(ctx context.Context) {
 	h.logger.Info("server exited")
 }
 
-func ready(w http.ResponseWriter, _ *http.Request) {
+// ready responds the status and the hash of the active config.
+func (h *Server) ready(w http.ResponseWriter, _ *http.Request) {
 	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(`{"status": "ok"}`))
+	_, _ = w.Write([]byte(`{"status": "ok", "config_hash": "` + h.config.Load().Hash + `"}`))
 }
 
 func (h *Server) Run(w http.ResponseWriter, r *http.Request) {
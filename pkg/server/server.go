@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"log/slog"
+	"sync/atomic"
 
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/controller"
@@ -10,18 +11,24 @@ import (
 
 type Server struct {
 	logger     *slog.Logger
-	config     *config.Config
+	logLevel   *slog.LevelVar
+	config     atomic.Pointer[config.Config]
+	source     *config.Source
 	controller Controller
 }
 
 type Controller interface {
 	Run(ctx context.Context, logger *slog.Logger, req *controller.Request) error
+	SetConfig(cfg *config.Config)
 }
 
-func New(logger *slog.Logger, ctrl Controller, cfg *config.Config) (*Server, error) {
-	return &Server{
+func New(logger *slog.Logger, logLevel *slog.LevelVar, ctrl Controller, cfg *config.Config, src *config.Source) (*Server, error) {
+	s := &Server{
 		logger:     logger,
-		config:     cfg,
+		logLevel:   logLevel,
+		source:     src,
 		controller: ctrl,
-	}, nil
+	}
+	s.config.Store(cfg)
+	return s, nil
 }
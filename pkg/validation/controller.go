@@ -23,15 +23,111 @@ type Input struct {
 	PR       *github.PullRequest
 	Trust    *Trust
 	Insecure *Insecure
+	Quorum   *Quorum
+	// CodeOwners are CODEOWNERS rules matching changed files.
+	// If CodeOwners is nil, code owner approvals aren't required.
+	CodeOwners []*CodeOwnerRule
+	// IgnoredCodeOwnersLines are lines of CODEOWNERS ignored because their patterns aren't supported.
+	IgnoredCodeOwnersLines []*IgnoredCodeOwnersLine
+	// SensitiveFiles are changed files matching sensitive path patterns.
+	SensitiveFiles []string
+	// If SignaturePolicy is nil, commits with valid signatures are trusted.
+	SignaturePolicy *SignaturePolicy
+	// If Keyring is set, commit signatures are also verified with the keyring.
+	Keyring *Keyring
+	// If Gitsign is set, x509 signatures are verified as gitsign signatures instead of GitHub.
+	Gitsign SignatureVerifier
+	// If BlockOnChangesRequested is true, reviews requesting changes from trusted reviewers block the approval.
+	BlockOnChangesRequested bool
+}
+
+// IgnoredCodeOwnersLine is a line of CODEOWNERS ignored because its pattern isn't supported.
+type IgnoredCodeOwnersLine struct {
+	Line    int
+	Pattern string
+}
+
+// CodeOwnerRule is a CODEOWNERS rule matching files changed in a pull request.
+// At least one approval from the owners is required.
+type CodeOwnerRule struct {
+	// Owners are owners written in CODEOWNERS such as `@octocat` and `@org/team`.
+	Owners []string
+	// Paths are changed files owned by the owners.
+	Paths []string
+	// Logins are lower-cased logins of the owners. Teams are expanded to their members.
+	Logins map[string]struct{}
+}
+
+// Quorum is the number of approvals required to approve a pull request.
+// If Quorum is nil, one approval is required, and two approvals are required if there are untrusted commits or self-approvals.
+type Quorum struct {
+	// RequiredApprovals is the number of approvals required if all commits are trusted and nobody self-approves.
+	RequiredApprovals int
+	// RequiredApprovalsWithUntrustedCommits is the number of approvals required if there are untrusted commits or self-approvals.
+	RequiredApprovalsWithUntrustedCommits int
+	// RequiredApprovalsForSensitivePaths is the number of approvals from non-committers required if sensitive files are changed.
+	RequiredApprovalsForSensitivePaths int
+}
+
+func (q *Quorum) required() int {
+	if q == nil || q.RequiredApprovals < 1 {
+		return 1
+	}
+	return q.RequiredApprovals
+}
+
+func (q *Quorum) requiredWithUntrustedCommits() int {
+	if q == nil || q.RequiredApprovalsWithUntrustedCommits < 1 {
+		return q.required() + 1
+	}
+	return max(q.RequiredApprovalsWithUntrustedCommits, q.required())
+}
+
+func (q *Quorum) requiredForSensitivePaths() int {
+	if q == nil || q.RequiredApprovalsForSensitivePaths < 1 {
+		return q.requiredWithUntrustedCommits()
+	}
+	return max(q.RequiredApprovalsForSensitivePaths, q.required())
+}
+
+type SignaturePolicy struct {
+	// AcceptedStates are accepted signature states. If AcceptedStates is empty, only VALID is accepted.
+	AcceptedStates map[string]struct{}
+	// Types are allowed signature types. If Types is empty, all types are allowed.
+	Types map[string]struct{}
+	// AllowedKeys are allowed keys per lower-cased login.
+	AllowedKeys map[string][]string
+}
+
+// SignatureVerifier verifies an armored signature over the raw commit object with keys of the login.
+type SignatureVerifier interface {
+	Verify(login, payload, signature string) error
+}
+
+// Keyring verifies commit signatures with keys configured per user instead of keys registered to GitHub accounts.
+type Keyring struct {
+	Verifier SignatureVerifier
+	// If Replace is true, signatures verified with the keyring are trusted regardless of GitHub's verification and SignaturePolicy.
+	// Otherwise, signatures must be verified with both the keyring and GitHub.
+	Replace bool
+}
+
+// Matcher reports whether a name such as a login matches patterns.
+// Team references in patterns must be resolved in advance.
+type Matcher interface {
+	Match(name string) bool
 }
 
 type Insecure struct {
 	AllowUnsignedCommits       bool
-	UnsignedCommitApps         map[string]struct{}
-	UnsignedCommitMachineUsers map[string]struct{}
+	UnsignedCommitApps         Matcher
+	UnsignedCommitMachineUsers Matcher
 }
 
 type Trust struct {
-	TrustedApps           map[string]struct{}
-	UntrustedMachineUsers []string
+	TrustedApps           Matcher
+	UntrustedMachineUsers Matcher
+	// ApproverMembers are lower-cased logins of approvers belonging to approver teams or approver organizations.
+	// If ApproverMembers is nil, approvers aren't restricted.
+	ApproverMembers map[string]struct{}
 }
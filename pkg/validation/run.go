@@ -1,9 +1,9 @@
 package validation
 
 import (
+	"fmt"
 	"log/slog"
 	"maps"
-	"path"
 	"slices"
 	"sort"
 	"strings"
@@ -20,29 +20,39 @@ func (c *Validator) Run(_ *slog.Logger, input *Input) *Result { //nolint:cyclop,
 	ignoredApprovers := make(map[string]*github.IgnoredApproval, len(pr.Approvers))
 	approvers := make(map[string]struct{}, len(pr.Approvers))
 	for approver, user := range pr.Approvers {
-		if user.IsApp {
-			if !c.VerifyApp(approver, input.Trust.TrustedApps) {
-				// Ignore the approval from untrusted apps
-				ignoredApprovers[approver] = &github.IgnoredApproval{
-					Login: approver,
-					IsApp: true,
-				}
-			}
+		if ignored := c.VerifyReviewer(approver, user, input.Trust); ignored != nil {
+			ignoredApprovers[approver] = ignored
 			continue
 		}
-		if !c.VerifyUser(approver, input.Trust) {
-			// Ignore the approval from untrusted machine users
-			ignoredApprovers[approver] = &github.IgnoredApproval{
-				Login:                  approver,
-				IsUntrustedMachineUser: true,
-			}
+		if user.IsApp {
 			continue
 		}
 		approvers[approver] = struct{}{}
 	}
 
-	if len(approvers) > 1 {
-		// Allow multiple approvals
+	result.ApprovalCount = len(approvers)
+	result.RequiredApprovals = input.Quorum.required()
+	result.SensitiveFiles = input.SensitiveFiles
+	result.IgnoredCodeOwnersLines = input.IgnoredCodeOwnersLines
+	if input.CodeOwners != nil {
+		result.MissingCodeOwners = c.VerifyCodeOwners(pr, approvers, input.CodeOwners)
+	}
+	if len(result.SensitiveFiles) > 0 {
+		// Only approvals from people who didn't contribute to commits count for sensitive files
+		result.ApprovalCount = countNonContributors(approvers, contributors(pr))
+		result.RequiredApprovals = input.Quorum.requiredForSensitivePaths()
+	}
+	if input.BlockOnChangesRequested {
+		result.ChangesRequesters = c.VerifyChangesRequesters(pr, input.Trust)
+		if len(result.ChangesRequesters) > 0 {
+			// Reviews requesting changes block the approval regardless of approvals
+			result.Approvers = slices.Sorted(maps.Keys(approvers))
+			result.State = StateChangesRequested
+			return result
+		}
+	}
+	if len(approvers) >= input.Quorum.requiredWithUntrustedCommits() && len(result.MissingCodeOwners) == 0 && len(result.SensitiveFiles) == 0 {
+		// The approvals are sufficient regardless of commits
 		result.Approvers = slices.Sorted(maps.Keys(approvers))
 		result.State = StateApproved
 		return result
@@ -59,69 +69,239 @@ func (c *Validator) Run(_ *slog.Logger, input *Input) *Result { //nolint:cyclop,
 		return result
 	}
 
-	// One approval
-
+	// GitHub logins are case-insensitive, so self-approvals are found by lower-cased logins
+	approverLogins := make(map[string]struct{}, len(approvers))
+	for approver := range approvers {
+		approverLogins[strings.ToLower(approver)] = struct{}{}
+	}
 	for _, commit := range pr.Commits {
-		if untrustedCommit := c.VerifyCommit(commit, input.Trust, input.Insecure); untrustedCommit != nil {
-			// Two approvals are required as there is an untrusted commit, but one approval is given
+		if untrustedCommit := c.VerifyCommit(commit, input.Trust, input.Insecure, input.SignaturePolicy, input.Keyring, input.Gitsign); untrustedCommit != nil {
+			// More approvals are required as there is an untrusted commit
 			result.UntrustedCommits = append(result.UntrustedCommits, untrustedCommit)
 			continue
 		}
-		committer := commit.Committer
-		login := committer.Login
-		if _, ok := approvers[login]; ok && !commit.IsAllowedMergeCommit {
-			// Only one approval is given, but it's a self approval.
+		if commit.IsAllowedMergeCommit {
 			// Clean merge commits (e.g., "Update branch") and empty commits
 			// are excluded from the self-approval check.
+			continue
+		}
+		for _, contributor := range commitContributors(commit) {
+			login := strings.ToLower(contributor.Login)
+			if _, ok := approverLogins[login]; !ok {
+				continue
+			}
+			// The approval is a self approval
 			if result.SelfApprovers == nil {
-				result.SelfApprovers = make(map[string]struct{})
+				result.SelfApprovers = make(map[string][]string)
+			}
+			if !slices.Contains(result.SelfApprovers[login], contributor.Role) {
+				result.SelfApprovers[login] = append(result.SelfApprovers[login], contributor.Role)
 			}
-			result.SelfApprovers[login] = struct{}{}
 		}
 	}
+	for _, push := range c.VerifyPushes(pr, approverLogins) {
+		// The approver pushed changes, so the approval is a self approval
+		login := strings.ToLower(push.Pusher.Login)
+		if result.SelfApprovers == nil {
+			result.SelfApprovers = make(map[string][]string)
+		}
+		if !slices.Contains(result.SelfApprovers[login], RolePusher) {
+			result.SelfApprovers[login] = append(result.SelfApprovers[login], RolePusher)
+		}
+		result.SelfApprovalPushes = append(result.SelfApprovalPushes, push)
+	}
 	if len(result.SelfApprovers) > 0 || len(result.UntrustedCommits) > 0 {
+		result.RequiredApprovals = max(result.RequiredApprovals, input.Quorum.requiredWithUntrustedCommits())
+	}
+	if result.ApprovalCount < result.RequiredApprovals || len(result.MissingCodeOwners) > 0 {
 		result.State = StateTwoApprovalsAreRequired
 		return result
 	}
-	// One approval is sufficient
-	// author and commits are trusted
+	// The approvals are sufficient
 	result.Approvers = slices.Sorted(maps.Keys(approvers))
 	result.State = StateApproved
 	return result
 }
 
-func (c *Validator) VerifyApp(login string, trustedApps map[string]struct{}) bool {
-	if _, ok := trustedApps[login]; ok {
-		return true
+// VerifyCodeOwners returns CODEOWNERS rules which no code owner approves.
+// Approvals from code owners who committed, authored, or co-authored commits of the pull request are ignored.
+// As with the self-approval check, clean merge commits and empty commits are excluded.
+func (c *Validator) VerifyCodeOwners(pr *github.PullRequest, approvers map[string]struct{}, rules []*CodeOwnerRule) []*CodeOwnerRule {
+	contributors := contributors(pr)
+	var missing []*CodeOwnerRule
+	for _, rule := range rules {
+		if !isApprovedByCodeOwner(rule, approvers, contributors) {
+			missing = append(missing, rule)
+		}
+	}
+	return missing
+}
+
+// VerifyPushes returns pushes by approvers which added non-trivial commits to the pull request.
+// Pushes whose commits were replaced by later pushes are ignored.
+// approvers are lower-cased logins.
+func (c *Validator) VerifyPushes(pr *github.PullRequest, approvers map[string]struct{}) []*github.Push {
+	commits := make(map[string]*github.Commit, len(pr.Commits))
+	for _, commit := range pr.Commits {
+		commits[commit.SHA] = commit
+	}
+	var pushes []*github.Push
+	for _, push := range pr.Pushes {
+		if push.Pusher == nil {
+			continue
+		}
+		if _, ok := approvers[strings.ToLower(push.Pusher.Login)]; !ok {
+			continue
+		}
+		commit, ok := commits[push.AfterSHA]
+		if !ok || commit.IsAllowedMergeCommit {
+			continue
+		}
+		pushes = append(pushes, push)
+	}
+	return pushes
+}
+
+// Roles of self-approvers
+const (
+	RoleCommitter = "committer"
+	RoleAuthor    = "author"
+	RoleCoAuthor  = "co-author"
+	RolePusher    = "pusher"
+)
+
+type contributor struct {
+	Login string
+	Role  string
+}
+
+// commitContributors returns the committer, the author, and co-authors of the commit.
+// Co-authors are written in Co-authored-by trailers of the commit message.
+func commitContributors(commit *github.Commit) []*contributor {
+	var contributors []*contributor
+	if commit.Committer != nil {
+		contributors = append(contributors, &contributor{Login: commit.Committer.Login, Role: RoleCommitter})
+	}
+	if commit.Author != nil {
+		contributors = append(contributors, &contributor{Login: commit.Author.Login, Role: RoleAuthor})
+	}
+	for _, user := range commit.CoAuthors {
+		contributors = append(contributors, &contributor{Login: user.Login, Role: RoleCoAuthor})
+	}
+	return contributors
+}
+
+// contributors returns lower-cased logins of committers, authors, co-authors, and pushers of commits of the pull request.
+// Clean merge commits and empty commits are excluded.
+func contributors(pr *github.PullRequest) map[string]struct{} {
+	m := make(map[string]struct{}, len(pr.Commits))
+	commits := make(map[string]struct{}, len(pr.Commits))
+	for _, commit := range pr.Commits {
+		if commit.IsAllowedMergeCommit {
+			continue
+		}
+		commits[commit.SHA] = struct{}{}
+		for _, c := range commitContributors(commit) {
+			m[strings.ToLower(c.Login)] = struct{}{}
+		}
+	}
+	for _, push := range pr.Pushes {
+		if push.Pusher == nil {
+			continue
+		}
+		if _, ok := commits[push.AfterSHA]; ok {
+			m[strings.ToLower(push.Pusher.Login)] = struct{}{}
+		}
+	}
+	return m
+}
+
+// countNonContributors returns the number of approvers who didn't contribute to commits of the pull request.
+func countNonContributors(approvers, contributors map[string]struct{}) int {
+	cnt := 0
+	for approver := range approvers {
+		if _, ok := contributors[strings.ToLower(approver)]; !ok {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+func isApprovedByCodeOwner(rule *CodeOwnerRule, approvers, contributors map[string]struct{}) bool {
+	for approver := range approvers {
+		login := strings.ToLower(approver)
+		if _, ok := contributors[login]; ok {
+			continue
+		}
+		if _, ok := rule.Logins[login]; ok {
+			return true
+		}
 	}
 	return false
 }
 
+func (c *Validator) VerifyApp(login string, trustedApps Matcher) bool {
+	return trustedApps != nil && trustedApps.Match(login)
+}
+
 func (c *Validator) VerifyUser(login string, trust *Trust) bool {
-	trusted := true
-	for _, pattern := range trust.UntrustedMachineUsers {
-		if neg, ok := strings.CutPrefix(pattern, "!"); ok {
-			matched, err := path.Match(neg, login)
-			if err != nil {
-				continue
-			}
-			if matched {
-				trusted = true
+	return trust.UntrustedMachineUsers == nil || !trust.UntrustedMachineUsers.Match(login)
+}
+
+// VerifyReviewer returns the reason why the review is ignored.
+// It returns nil if the reviewer is trusted.
+// Approvals from trusted apps are ignored without reasons.
+func (c *Validator) VerifyReviewer(login string, user *github.User, trust *Trust) *github.IgnoredApproval {
+	if user.IsApp {
+		if !c.VerifyApp(login, trust.TrustedApps) {
+			// Ignore the review from untrusted apps
+			return &github.IgnoredApproval{
+				Login: login,
+				IsApp: true,
 			}
-			continue
 		}
-		matched, err := path.Match(pattern, login)
-		if err != nil {
-			continue
+		return nil
+	}
+	if !c.VerifyUser(login, trust) {
+		// Ignore the review from untrusted machine users
+		return &github.IgnoredApproval{
+			Login:                  login,
+			IsUntrustedMachineUser: true,
 		}
-		if matched {
-			trusted = false
+	}
+	if !c.VerifyMember(login, trust) {
+		// Ignore the review from users outside approver teams and organizations
+		return &github.IgnoredApproval{
+			Login:       login,
+			IsNotMember: true,
 		}
 	}
-	return trusted
+	return nil
 }
 
-func (c *Validator) VerifyCommit(commit *github.Commit, trust *Trust, insecure *Insecure) *github.UntrustedCommit {
+// VerifyChangesRequesters returns sorted logins of trusted reviewers whose latest review requests changes.
+func (c *Validator) VerifyChangesRequesters(pr *github.PullRequest, trust *Trust) []string {
+	var requesters []string
+	for login, user := range pr.ChangesRequesters {
+		if c.VerifyReviewer(login, user, trust) == nil {
+			requesters = append(requesters, login)
+		}
+	}
+	slices.Sort(requesters)
+	return requesters
+}
+
+// VerifyMember reports whether the user belongs to approver teams or approver organizations.
+// If approvers aren't restricted, it returns true.
+func (c *Validator) VerifyMember(login string, trust *Trust) bool {
+	if trust.ApproverMembers == nil {
+		return true
+	}
+	_, ok := trust.ApproverMembers[strings.ToLower(login)]
+	return ok
+}
+
+func (c *Validator) VerifyCommit(commit *github.Commit, trust *Trust, insecure *Insecure, policy *SignaturePolicy, keyring *Keyring, gitsign SignatureVerifier) *github.UntrustedCommit {
 	sha := commit.SHA
 	user := commit.Committer
 	if user == nil {
@@ -137,18 +317,18 @@ func (c *Validator) VerifyCommit(commit *github.Commit, trust *Trust, insecure *
 			SHA:             sha,
 		}
 	}
-	sig := commit.Signature
-	if sig == nil || !sig.IsValid {
+	if user.IsApp || (commit.Signature != nil && commit.Signature.WasSignedByGitHub) {
+		// Apps and GitHub have no keys in the keyring, so their signatures are verified only by GitHub.
+		// Signatures of GitHub can't be made with throwaway keys.
+		keyring = nil
+	}
+	if untrustedCommit := c.VerifySignature(commit, login, policy, keyring, gitsign); untrustedCommit != nil {
 		if !isUnsignedCommitAllowed(login, insecure) {
-			return &github.UntrustedCommit{
-				Login:       login,
-				SHA:         sha,
-				InvalidSign: sig,
-			}
+			return untrustedCommit
 		}
 	}
 	if user.IsApp {
-		if _, ok := trust.TrustedApps[login]; ok {
+		if c.VerifyApp(login, trust.TrustedApps) {
 			return nil
 		}
 		return &github.UntrustedCommit{
@@ -172,8 +352,25 @@ type Result struct {
 	Error          string
 	State          State
 	CarriedForward bool
-	Approvers      []string
-	SelfApprovers  map[string]struct{}
+	// the problem of the repository config file
+	RepoConfigError string
+	Approvers       []string
+	// lower-cased logins of approvers who contributed to commits and their roles such as committer, author, co-author, and pusher
+	SelfApprovers map[string][]string
+	// pushes by self-approvers
+	SelfApprovalPushes []*github.Push
+	// trusted reviewers whose latest review requests changes
+	ChangesRequesters []string
+	// CODEOWNERS rules which no code owner approves
+	MissingCodeOwners []*CodeOwnerRule
+	// lines of CODEOWNERS ignored because their patterns aren't supported
+	IgnoredCodeOwnersLines []*IgnoredCodeOwnersLine
+	// changed files matching sensitive path patterns
+	SensitiveFiles []string
+	// the number of valid approvals
+	ApprovalCount int
+	// the number of approvals required to approve the pull request
+	RequiredApprovals int
 	// app or untrusted machine user approvals
 	IgnoredApprovers []*github.IgnoredApproval
 	// app
@@ -184,6 +381,8 @@ type Result struct {
 	// settings
 	TrustedApps           []string
 	UntrustedMachineUsers []string
+	ApproverTeams         []string
+	ApproverOrgs          []string
 	// insecure settings
 	AllowUnsignedCommits       bool
 	UnsignedCommitApps         []string
@@ -191,6 +390,95 @@ type Result struct {
 	Version                    string
 }
 
+// VerifySignature verifies the commit signature.
+// If policy is nil, a signature is valid if GitHub verifies it.
+// If keyring is set, the signature must also be verified with the keyring.
+// If gitsign is set, x509 signatures are verified with it instead of GitHub and the keyring.
+func (c *Validator) VerifySignature(commit *github.Commit, login string, policy *SignaturePolicy, keyring *Keyring, gitsign SignatureVerifier) *github.UntrustedCommit {
+	sig := commit.Signature
+	untrusted := &github.UntrustedCommit{
+		Login:       login,
+		SHA:         commit.SHA,
+		InvalidSign: sig,
+	}
+	if sig == nil {
+		return untrusted
+	}
+	if gitsign != nil && sig.Type() == github.SignatureTypeX509 {
+		return verifyGitsign(gitsign, sig, login, policy, untrusted)
+	}
+	if keyring != nil {
+		if err := keyring.Verifier.Verify(login, sig.Payload, sig.Signature); err != nil {
+			untrusted.KeyringError = err.Error()
+			return untrusted
+		}
+		if keyring.Replace {
+			return nil
+		}
+	}
+	if policy == nil {
+		if sig.IsValid {
+			return nil
+		}
+		return untrusted
+	}
+	if len(policy.AcceptedStates) == 0 {
+		if sig.State != "VALID" {
+			untrusted.SignatureStateRejected = true
+			return untrusted
+		}
+	} else if _, ok := policy.AcceptedStates[sig.State]; !ok {
+		untrusted.SignatureStateRejected = true
+		return untrusted
+	}
+	if len(policy.Types) > 0 {
+		if _, ok := policy.Types[sig.Type()]; !ok {
+			untrusted.SignatureTypeRejected = true
+			return untrusted
+		}
+	}
+	if keys, ok := policy.AllowedKeys[strings.ToLower(login)]; ok && !matchKey(sig, keys) {
+		untrusted.SignatureKeyRejected = true
+		return untrusted
+	}
+	return nil
+}
+
+// verifyGitsign verifies a gitsign signature, which GitHub can't verify.
+// The signature policy's types still apply, but accepted_states and allowed_keys don't.
+func verifyGitsign(gitsign SignatureVerifier, sig *github.Signature, login string, policy *SignaturePolicy, untrusted *github.UntrustedCommit) *github.UntrustedCommit {
+	if policy != nil && len(policy.Types) > 0 {
+		if _, ok := policy.Types[github.SignatureTypeX509]; !ok {
+			untrusted.SignatureTypeRejected = true
+			return untrusted
+		}
+	}
+	if err := gitsign.Verify(login, sig.Payload, sig.Signature); err != nil {
+		untrusted.GitsignError = err.Error()
+		return untrusted
+	}
+	return nil
+}
+
+// matchKey reports whether the signing key is included in keys.
+// A GPG key ID matches a fingerprint ending with it, ignoring case and spaces.
+func matchKey(sig *github.Signature, keys []string) bool {
+	key := sig.Key()
+	if key == "" {
+		return false
+	}
+	if sig.Type() != github.SignatureTypeGPG {
+		return slices.Contains(keys, key)
+	}
+	key = strings.ToUpper(key)
+	for _, k := range keys {
+		if strings.HasSuffix(strings.ToUpper(strings.ReplaceAll(k, " ", "")), key) {
+			return true
+		}
+	}
+	return false
+}
+
 func isUnsignedCommitAllowed(login string, insecure *Insecure) bool {
 	if insecure == nil {
 		return false
@@ -198,16 +486,20 @@ func isUnsignedCommitAllowed(login string, insecure *Insecure) bool {
 	if insecure.AllowUnsignedCommits {
 		return true
 	}
-	// Check apps: strip [bot] suffix from login for lookup
-	appName := strings.TrimSuffix(login, "[bot]")
-	if _, ok := insecure.UnsignedCommitApps[appName]; ok {
+	// App patterns ignore the [bot] suffix
+	if insecure.UnsignedCommitApps != nil && insecure.UnsignedCommitApps.Match(login) {
 		return true
 	}
-	// Check machine users: exact match
-	if _, ok := insecure.UnsignedCommitMachineUsers[login]; ok {
-		return true
+	return insecure.UnsignedCommitMachineUsers != nil && insecure.UnsignedCommitMachineUsers.Match(login)
+}
+
+// ApprovalSummary returns the number of approvals and required approvals like "1 approval, 2 required".
+// The number of approvals may exceed the required approvals, so they aren't shown as a fraction.
+func (r *Result) ApprovalSummary() string {
+	if r.ApprovalCount == 1 {
+		return fmt.Sprintf("1 approval, %d required", r.RequiredApprovals)
 	}
-	return false
+	return fmt.Sprintf("%d approvals, %d required", r.ApprovalCount, r.RequiredApprovals)
 }
 
 func (r *Result) Reasons() []string {
@@ -215,8 +507,13 @@ func (r *Result) Reasons() []string {
 	hasUnsigned := false
 	hasUntrustedApp := false
 	hasUntrustedMachineUser := false
+	hasSignaturePolicyViolation := false
 	for _, c := range r.UntrustedCommits {
-		if !hasUnsigned && (c.NotLinkedToUser || c.InvalidSign != nil) {
+		if !hasSignaturePolicyViolation && c.ViolatesSignaturePolicy() {
+			hasSignaturePolicyViolation = true
+			reasons = append(reasons, "signature policy violations")
+		}
+		if !hasUnsigned && (c.NotLinkedToUser || c.InvalidSign != nil) && !c.ViolatesSignaturePolicy() {
 			hasUnsigned = true
 			reasons = append(reasons, "unsigned commits")
 		}
@@ -232,6 +529,12 @@ func (r *Result) Reasons() []string {
 	if len(r.SelfApprovers) > 0 {
 		reasons = append(reasons, "self-approval")
 	}
+	if len(r.MissingCodeOwners) > 0 {
+		reasons = append(reasons, "code owner approvals")
+	}
+	if len(r.SensitiveFiles) > 0 {
+		reasons = append(reasons, "sensitive paths")
+	}
 	return reasons
 }
 
@@ -240,5 +543,8 @@ type State string
 const (
 	StateApproved                State = "approved"
 	StateApprovalIsRequired      State = "no_approval"
-	StateTwoApprovalsAreRequired State = "require_two_approvals"
+	StateTwoApprovalsAreRequired State = "require_two_approvals" // more approvals are required
+	StateChangesRequested        State = "changes_requested"
+	// the repository config file is invalid
+	StateInvalidRepoConfig State = "invalid_repo_config"
 )
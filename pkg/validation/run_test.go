@@ -2,13 +2,32 @@
 package validation_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/config"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github"
+	v4 "github.com/suzuki-shunsuke/validate-pr-review-app/pkg/github/v4"
 	"github.com/suzuki-shunsuke/validate-pr-review-app/pkg/validation"
 )
 
+func appMatcher(patterns ...string) *config.Matcher {
+	m, err := config.NewAppMatcher(patterns)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func userMatcher(patterns ...string) *config.Matcher {
+	m, err := config.NewUserMatcher(patterns)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
 func TestController_Run(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -42,12 +61,14 @@ func TestController_Run(t *testing.T) {
 					},
 				},
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 			},
 			expected: &validation.Result{
-				State:     validation.StateApproved,
-				Approvers: []string{"reviewer1", "reviewer2"},
+				State:             validation.StateApproved,
+				ApprovalCount:     2,
+				RequiredApprovals: 1,
+				Approvers:         []string{"reviewer1", "reviewer2"},
 			},
 		},
 		{
@@ -55,7 +76,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA:   "abc123",
@@ -72,7 +93,8 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State: validation.StateApprovalIsRequired,
+				State:             validation.StateApprovalIsRequired,
+				RequiredApprovals: 1,
 			},
 		},
 		{
@@ -80,7 +102,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -103,8 +125,10 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State:         validation.StateTwoApprovalsAreRequired,
-				SelfApprovers: map[string]struct{}{"committer": {}},
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
+				SelfApprovers:     map[string][]string{"committer": {"committer"}},
 			},
 		},
 		{
@@ -112,7 +136,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -135,8 +159,10 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State:     validation.StateApproved,
-				Approvers: []string{"reviewer1"},
+				State:             validation.StateApproved,
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+				Approvers:         []string{"reviewer1"},
 			},
 		},
 		{
@@ -144,7 +170,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -163,7 +189,8 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State: validation.StateApprovalIsRequired,
+				State:             validation.StateApprovalIsRequired,
+				RequiredApprovals: 1,
 				IgnoredApprovers: []*github.IgnoredApproval{
 					{
 						Login: "bot-app[bot]",
@@ -177,7 +204,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -196,7 +223,8 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State: validation.StateApprovalIsRequired,
+				State:             validation.StateApprovalIsRequired,
+				RequiredApprovals: 1,
 				IgnoredApprovers: []*github.IgnoredApproval{
 					{
 						Login: "coderabbitai",
@@ -210,8 +238,8 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps:           map[string]struct{}{},
-					UntrustedMachineUsers: []string{"untrusted-*"},
+					TrustedApps:           appMatcher(),
+					UntrustedMachineUsers: userMatcher("untrusted-*"),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -230,7 +258,8 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State: validation.StateApprovalIsRequired,
+				State:             validation.StateApprovalIsRequired,
+				RequiredApprovals: 1,
 				IgnoredApprovers: []*github.IgnoredApproval{
 					{
 						Login:                  "untrusted-bot",
@@ -244,8 +273,8 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps:           map[string]struct{}{},
-					UntrustedMachineUsers: []string{"trusted-*", "!trusted-bot"},
+					TrustedApps:           appMatcher(),
+					UntrustedMachineUsers: userMatcher("trusted-*", "!trusted-bot"),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -268,8 +297,10 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State:     validation.StateApproved,
-				Approvers: []string{"trusted-bot"},
+				State:             validation.StateApproved,
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+				Approvers:         []string{"trusted-bot"},
 			},
 		},
 		{
@@ -277,7 +308,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -300,7 +331,9 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State: validation.StateTwoApprovalsAreRequired,
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
 				UntrustedCommits: []*github.UntrustedCommit{
 					{
 						Login: "committer",
@@ -317,7 +350,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -340,7 +373,9 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State: validation.StateTwoApprovalsAreRequired,
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
 				UntrustedCommits: []*github.UntrustedCommit{
 					{
 						Login:          "untrusted-app[bot]",
@@ -355,7 +390,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				Insecure: &validation.Insecure{
 					AllowUnsignedCommits: true,
@@ -381,8 +416,10 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State:     validation.StateApproved,
-				Approvers: []string{"reviewer1"},
+				State:             validation.StateApproved,
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+				Approvers:         []string{"reviewer1"},
 			},
 		},
 		{
@@ -390,10 +427,10 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				Insecure: &validation.Insecure{
-					UnsignedCommitMachineUsers: map[string]struct{}{"machine-user": {}},
+					UnsignedCommitMachineUsers: userMatcher("machine-user"),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -413,8 +450,10 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State:     validation.StateApproved,
-				Approvers: []string{"reviewer1"},
+				State:             validation.StateApproved,
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+				Approvers:         []string{"reviewer1"},
 			},
 		},
 		{
@@ -422,10 +461,10 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				Insecure: &validation.Insecure{
-					UnsignedCommitMachineUsers: map[string]struct{}{"machine-user": {}},
+					UnsignedCommitMachineUsers: userMatcher("machine-user"),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -448,7 +487,9 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State: validation.StateTwoApprovalsAreRequired,
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
 				UntrustedCommits: []*github.UntrustedCommit{
 					{
 						Login: "other-user",
@@ -465,10 +506,10 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{"eks-cluster-upgrade-ci[bot]": {}},
+					TrustedApps: appMatcher("eks-cluster-upgrade-ci[bot]"),
 				},
 				Insecure: &validation.Insecure{
-					UnsignedCommitApps: map[string]struct{}{"eks-cluster-upgrade-ci": {}},
+					UnsignedCommitApps: appMatcher("eks-cluster-upgrade-ci"),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -488,8 +529,10 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State:     validation.StateApproved,
-				Approvers: []string{"reviewer1"},
+				State:             validation.StateApproved,
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+				Approvers:         []string{"reviewer1"},
 			},
 		},
 		{
@@ -497,7 +540,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -517,7 +560,9 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State: validation.StateTwoApprovalsAreRequired,
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
 				UntrustedCommits: []*github.UntrustedCommit{
 					{
 						Login: "committer",
@@ -531,7 +576,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -556,8 +601,10 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State:     validation.StateApproved,
-				Approvers: []string{"committer"},
+				State:             validation.StateApproved,
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+				Approvers:         []string{"committer"},
 			},
 		},
 		{
@@ -565,7 +612,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -602,8 +649,10 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State:         validation.StateTwoApprovalsAreRequired,
-				SelfApprovers: map[string]struct{}{"committer": {}},
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
+				SelfApprovers:     map[string][]string{"committer": {"committer"}},
 			},
 		},
 		{
@@ -611,7 +660,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -636,8 +685,10 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State:     validation.StateApproved,
-				Approvers: []string{"committer"},
+				State:             validation.StateApproved,
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+				Approvers:         []string{"committer"},
 			},
 		},
 		{
@@ -645,7 +696,7 @@ func TestController_Run(t *testing.T) {
 			inputNew: &validation.InputNew{},
 			input: &validation.Input{
 				Trust: &validation.Trust{
-					TrustedApps: map[string]struct{}{},
+					TrustedApps: appMatcher(),
 				},
 				PR: &github.PullRequest{
 					HeadSHA: "abc123",
@@ -664,7 +715,9 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 			expected: &validation.Result{
-				State: validation.StateTwoApprovalsAreRequired,
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
 				UntrustedCommits: []*github.UntrustedCommit{
 					{
 						SHA:             "abc123",
@@ -673,145 +726,1147 @@ func TestController_Run(t *testing.T) {
 				},
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-			ctrl := validation.New(tt.inputNew)
-			result := ctrl.Run(nil, tt.input)
-
-			if diff := cmp.Diff(tt.expected, result); diff != "" {
-				t.Errorf("validation result mismatch (-want +got):\n%s", diff)
-			}
-		})
-	}
-}
-
-func TestResult_Reasons(t *testing.T) {
-	t.Parallel()
-	tests := []struct {
-		name     string
-		result   *validation.Result
-		expected []string
-	}{
-		{
-			name:     "no reasons",
-			result:   &validation.Result{},
-			expected: nil,
-		},
 		{
-			name: "self-approval only",
-			result: &validation.Result{
-				SelfApprovers: map[string]struct{}{"user1": {}},
+			name:     "quorum 2/3 with one trusted approval - more approvals required",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				Quorum: &validation.Quorum{
+					RequiredApprovals:                     2,
+					RequiredApprovalsWithUntrustedCommits: 3,
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"reviewer1": {Login: "reviewer1"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
 			},
-			expected: []string{"self-approval"},
 		},
 		{
-			name: "unsigned commit - InvalidSign",
-			result: &validation.Result{
+			name:     "quorum 2/3 with two approvals and untrusted commit - more approvals required",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				Quorum: &validation.Quorum{
+					RequiredApprovals:                     2,
+					RequiredApprovalsWithUntrustedCommits: 3,
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"reviewer1": {Login: "reviewer1"},
+						"reviewer2": {Login: "reviewer2"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     2,
+				RequiredApprovals: 3,
 				UntrustedCommits: []*github.UntrustedCommit{
 					{
-						Login:       "user1",
-						SHA:         "abc123",
-						InvalidSign: &github.Signature{State: "invalid"},
+						Login: "committer",
+						SHA:   "abc123",
 					},
 				},
 			},
-			expected: []string{"unsigned commits"},
 		},
 		{
-			name: "unsigned commit - NotLinkedToUser",
-			result: &validation.Result{
-				UntrustedCommits: []*github.UntrustedCommit{
-					{
-						SHA:             "abc123",
-						NotLinkedToUser: true,
+			name:     "quorum 2/3 with two approvals and trusted commits - approved",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				Quorum: &validation.Quorum{
+					RequiredApprovals:                     2,
+					RequiredApprovalsWithUntrustedCommits: 3,
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"reviewer1": {Login: "reviewer1"},
+						"reviewer2": {Login: "reviewer2"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
 					},
 				},
 			},
-			expected: []string{"unsigned commits"},
+			expected: &validation.Result{
+				State:             validation.StateApproved,
+				Approvers:         []string{"reviewer1", "reviewer2"},
+				ApprovalCount:     2,
+				RequiredApprovals: 2,
+			},
 		},
 		{
-			name: "untrusted app commit only",
-			result: &validation.Result{
-				UntrustedCommits: []*github.UntrustedCommit{
+			name:     "code owner approval - approved",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				CodeOwners: []*validation.CodeOwnerRule{
 					{
-						Login:          "app[bot]",
-						SHA:            "abc123",
-						IsUntrustedApp: true,
+						Owners: []string{"@org/infra"},
+						Paths:  []string{"terraform/main.tf"},
+						Logins: map[string]struct{}{"reviewer1": {}},
+					},
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"Reviewer1": {Login: "Reviewer1"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
 					},
 				},
 			},
-			expected: []string{"untrusted app commits"},
+			expected: &validation.Result{
+				State:             validation.StateApproved,
+				Approvers:         []string{"Reviewer1"},
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+			},
 		},
 		{
-			name: "untrusted machine user commit only",
-			result: &validation.Result{
-				UntrustedCommits: []*github.UntrustedCommit{
+			name:     "code owner approval is a self approval - more approvals required",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				CodeOwners: []*validation.CodeOwnerRule{
 					{
-						Login:                  "bot-user",
-						SHA:                    "abc123",
-						IsUntrustedMachineUser: true,
+						Owners: []string{"@committer"},
+						Paths:  []string{"terraform/main.tf"},
+						Logins: map[string]struct{}{"committer": {}},
+					},
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"committer": {Login: "committer"},
+						"reviewer1": {Login: "reviewer1"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
 					},
 				},
 			},
-			expected: []string{"untrusted machine user commits"},
-		},
-		{
-			name: "unsigned commit and self-approval",
-			result: &validation.Result{
-				SelfApprovers: map[string]struct{}{"user1": {}},
-				UntrustedCommits: []*github.UntrustedCommit{
+			expected: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     2,
+				RequiredApprovals: 2,
+				SelfApprovers:     map[string][]string{"committer": {"committer"}},
+				MissingCodeOwners: []*validation.CodeOwnerRule{
 					{
-						Login:       "user1",
-						SHA:         "abc123",
-						InvalidSign: &github.Signature{State: "invalid"},
+						Owners: []string{"@committer"},
+						Paths:  []string{"terraform/main.tf"},
+						Logins: map[string]struct{}{"committer": {}},
 					},
 				},
 			},
-			expected: []string{"unsigned commits", "self-approval"},
 		},
 		{
-			name: "multiple unsigned commits - deduplicated",
-			result: &validation.Result{
-				UntrustedCommits: []*github.UntrustedCommit{
+			name:     "no approvals with code owners - approval required",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				CodeOwners: []*validation.CodeOwnerRule{
 					{
-						Login:       "user1",
-						SHA:         "abc123",
-						InvalidSign: &github.Signature{State: "invalid"},
+						Owners: []string{"@octocat"},
+						Paths:  []string{"README.md"},
+						Logins: map[string]struct{}{"octocat": {}},
+					},
+				},
+				PR: &github.PullRequest{
+					HeadSHA:   "abc123",
+					Approvers: map[string]*github.User{},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+						},
 					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateApprovalIsRequired,
+				RequiredApprovals: 1,
+				MissingCodeOwners: []*validation.CodeOwnerRule{
 					{
-						SHA:             "def456",
-						NotLinkedToUser: true,
+						Owners: []string{"@octocat"},
+						Paths:  []string{"README.md"},
+						Logins: map[string]struct{}{"octocat": {}},
 					},
 				},
 			},
-			expected: []string{"unsigned commits"},
 		},
 		{
-			name: "all reasons combined",
-			result: &validation.Result{
-				SelfApprovers: map[string]struct{}{"user1": {}},
-				UntrustedCommits: []*github.UntrustedCommit{
-					{
-						Login:           "user1",
-						SHA:             "abc123",
-						NotLinkedToUser: true,
-					},
-					{
-						Login:          "app[bot]",
-						SHA:            "def456",
-						IsUntrustedApp: true,
+			name:     "quorum 1/1 with self approval - approved",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				Quorum: &validation.Quorum{
+					RequiredApprovals:                     1,
+					RequiredApprovalsWithUntrustedCommits: 1,
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"committer": {Login: "committer"},
 					},
-					{
-						Login:                  "bot-user",
-						SHA:                    "ghi789",
-						IsUntrustedMachineUser: true,
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+						},
 					},
 				},
 			},
-			expected: []string{"unsigned commits", "untrusted app commits", "untrusted machine user commits", "self-approval"},
+			expected: &validation.Result{
+				State:             validation.StateApproved,
+				Approvers:         []string{"committer"},
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+			},
+		},
+		{
+			name:     "sensitive files with two approvals including a self approval - more approvals required",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				SensitiveFiles: []string{".github/workflows/test.yaml"},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"committer": {Login: "committer"},
+						"reviewer1": {Login: "reviewer1"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
+				SelfApprovers:     map[string][]string{"committer": {"committer"}},
+				SensitiveFiles:    []string{".github/workflows/test.yaml"},
+			},
+		},
+		{
+			name:     "sensitive files with two non-self approvals - approved",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				Quorum: &validation.Quorum{
+					RequiredApprovals:                     1,
+					RequiredApprovalsWithUntrustedCommits: 1,
+					RequiredApprovalsForSensitivePaths:    2,
+				},
+				SensitiveFiles: []string{"terraform/prod/main.tf"},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"reviewer1": {Login: "reviewer1"},
+						"reviewer2": {Login: "reviewer2"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateApproved,
+				Approvers:         []string{"reviewer1", "reviewer2"},
+				ApprovalCount:     2,
+				RequiredApprovals: 2,
+				SensitiveFiles:    []string{"terraform/prod/main.tf"},
+			},
+		},
+		{
+			name:     "approval from a user outside approver teams is ignored",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps:     appMatcher(),
+					ApproverMembers: map[string]struct{}{"reviewer1": {}},
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"Reviewer1": {Login: "Reviewer1"},
+						"outsider":  {Login: "outsider"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateApproved,
+				Approvers:         []string{"Reviewer1"},
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+				IgnoredApprovers: []*github.IgnoredApproval{
+					{
+						Login:       "outsider",
+						IsNotMember: true,
+					},
+				},
+			},
+		},
+		{
+			name:     "no approvals from approver teams - approval required",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps:     appMatcher(),
+					ApproverMembers: map[string]struct{}{},
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"outsider": {Login: "outsider"},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateApprovalIsRequired,
+				RequiredApprovals: 1,
+				IgnoredApprovers: []*github.IgnoredApproval{
+					{
+						Login:       "outsider",
+						IsNotMember: true,
+					},
+				},
+			},
+		},
+		{
+			name:     "changes requested blocks sufficient approvals",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				BlockOnChangesRequested: true,
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"reviewer1": {Login: "reviewer1"},
+						"reviewer2": {Login: "reviewer2"},
+					},
+					ChangesRequesters: map[string]*github.User{
+						"reviewer3": {Login: "reviewer3"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateChangesRequested,
+				Approvers:         []string{"reviewer1", "reviewer2"},
+				ChangesRequesters: []string{"reviewer3"},
+				ApprovalCount:     2,
+				RequiredApprovals: 1,
+			},
+		},
+		{
+			name:     "changes requested by untrusted reviewers are ignored",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps:           appMatcher(),
+					UntrustedMachineUsers: userMatcher("*-bot"),
+				},
+				BlockOnChangesRequested: true,
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"reviewer1": {Login: "reviewer1"},
+						"reviewer2": {Login: "reviewer2"},
+					},
+					ChangesRequesters: map[string]*github.User{
+						"foo-bot":        {Login: "foo-bot"},
+						"untrusted[bot]": {Login: "untrusted[bot]", IsApp: true},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateApproved,
+				Approvers:         []string{"reviewer1", "reviewer2"},
+				ApprovalCount:     2,
+				RequiredApprovals: 1,
+			},
+		},
+		{
+			name:     "changes requested don't block approvals if the option is disabled",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"reviewer1": {Login: "reviewer1"},
+						"reviewer2": {Login: "reviewer2"},
+					},
+					ChangesRequesters: map[string]*github.User{
+						"reviewer3": {Login: "reviewer3"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "committer",
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateApproved,
+				Approvers:         []string{"reviewer1", "reviewer2"},
+				ApprovalCount:     2,
+				RequiredApprovals: 1,
+			},
+		},
+		{
+			name:     "approval from a co-author - more approvals required",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "abc123",
+					Approvers: map[string]*github.User{
+						"co-author": {Login: "co-author"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA: "abc123",
+							Committer: &github.User{
+								Login: "web-flow",
+							},
+							Author: &github.User{
+								Login: "author",
+							},
+							CoAuthors: []*github.User{
+								{Login: "co-author"},
+							},
+							Signature: &github.Signature{
+								IsValid: true,
+								State:   "valid",
+							},
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
+				SelfApprovers: map[string][]string{
+					"co-author": {"co-author"},
+				},
+			},
+		},
+		{
+			name:     "approval from the committer and author of commits - roles are merged",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "def456",
+					Approvers: map[string]*github.User{
+						"octocat": {Login: "octocat"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA:       "abc123",
+							Committer: &github.User{Login: "octocat"},
+							Author:    &github.User{Login: "octocat"},
+							Signature: &github.Signature{IsValid: true, State: "valid"},
+						},
+						{
+							SHA:       "def456",
+							Committer: &github.User{Login: "octocat"},
+							Author:    &github.User{Login: "someone"},
+							Signature: &github.Signature{IsValid: true, State: "valid"},
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
+				SelfApprovers: map[string][]string{
+					"octocat": {"committer", "author"},
+				},
+			},
+		},
+		{
+			name:     "logins of approvers and contributors differ in case - roles are merged",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "def456",
+					Approvers: map[string]*github.User{
+						"OctoCat": {Login: "OctoCat"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA:       "def456",
+							Committer: &github.User{Login: "octocat"},
+							Author:    &github.User{Login: "Octocat"},
+							Signature: &github.Signature{IsValid: true, State: "valid"},
+						},
+					},
+					Pushes: []*github.Push{
+						{
+							Pusher:   &github.User{Login: "OCTOCAT"},
+							AfterSHA: "def456",
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
+				SelfApprovers: map[string][]string{
+					"octocat": {"committer", "author", "pusher"},
+				},
+				SelfApprovalPushes: []*github.Push{
+					{
+						Pusher:   &github.User{Login: "OCTOCAT"},
+						AfterSHA: "def456",
+					},
+				},
+			},
+		},
+		{
+			name:     "approval from a user who force-pushed commits - more approvals required",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "def456",
+					Approvers: map[string]*github.User{
+						"pusher": {Login: "pusher"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA:       "def456",
+							Committer: &github.User{Login: "committer"},
+							Signature: &github.Signature{IsValid: true, State: "valid"},
+						},
+					},
+					Pushes: []*github.Push{
+						{
+							// the pushed commit was replaced by a later push
+							Pusher:      &github.User{Login: "pusher"},
+							AfterSHA:    "abc123",
+							ForcePushed: true,
+						},
+						{
+							Pusher:      &github.User{Login: "pusher"},
+							BeforeSHA:   "abc123",
+							AfterSHA:    "def456",
+							ForcePushed: true,
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateTwoApprovalsAreRequired,
+				ApprovalCount:     1,
+				RequiredApprovals: 2,
+				SelfApprovers: map[string][]string{
+					"pusher": {"pusher"},
+				},
+				SelfApprovalPushes: []*github.Push{
+					{
+						Pusher:      &github.User{Login: "pusher"},
+						BeforeSHA:   "abc123",
+						AfterSHA:    "def456",
+						ForcePushed: true,
+					},
+				},
+			},
+		},
+		{
+			name:     "approval from a user who force-pushed a clean merge commit - approved",
+			inputNew: &validation.InputNew{},
+			input: &validation.Input{
+				Trust: &validation.Trust{
+					TrustedApps: appMatcher(),
+				},
+				PR: &github.PullRequest{
+					HeadSHA: "def456",
+					Approvers: map[string]*github.User{
+						"pusher": {Login: "pusher"},
+					},
+					Commits: []*github.Commit{
+						{
+							SHA:                  "def456",
+							Committer:            &github.User{Login: "committer"},
+							Signature:            &github.Signature{IsValid: true, State: "valid"},
+							IsAllowedMergeCommit: true,
+						},
+					},
+					Pushes: []*github.Push{
+						{
+							Pusher:      &github.User{Login: "pusher"},
+							AfterSHA:    "def456",
+							ForcePushed: true,
+						},
+					},
+				},
+			},
+			expected: &validation.Result{
+				State:             validation.StateApproved,
+				Approvers:         []string{"pusher"},
+				ApprovalCount:     1,
+				RequiredApprovals: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := validation.New(tt.inputNew)
+			result := ctrl.Run(nil, tt.input)
+
+			if diff := cmp.Diff(tt.expected, result); diff != "" {
+				t.Errorf("validation result mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// verifierFunc verifies signatures with a function instead of keys.
+type verifierFunc func(login, payload, signature string) error
+
+func (f verifierFunc) Verify(login, payload, signature string) error {
+	return f(login, payload, signature)
+}
+
+func TestValidator_VerifySignature(t *testing.T) {
+	t.Parallel()
+	verified := &validation.Keyring{
+		Verifier: verifierFunc(func(_, _, _ string) error { return nil }),
+	}
+	rejected := &validation.Keyring{
+		Verifier: verifierFunc(func(login, _, _ string) error {
+			return errors.New("no key is registered for " + login)
+		}),
+	}
+	gpg := &github.Signature{
+		IsValid:      true,
+		State:        "VALID",
+		Typename:     "GpgSignature",
+		GpgSignature: v4.GpgSignature{KeyID: "3AFDEB23AAAABBBB"},
+	}
+	ssh := &github.Signature{
+		IsValid:      true,
+		State:        "VALID",
+		Typename:     "SshSignature",
+		SSHSignature: v4.SSHSignature{KeyFingerprint: "SHA256:abc"},
+	}
+	unverifiedEmail := &github.Signature{
+		IsValid:  true,
+		State:    "UNVERIFIED_EMAIL",
+		Typename: "GpgSignature",
+	}
+	x509 := &github.Signature{
+		State:    "UNKNOWN_SIG_TYPE",
+		Typename: "SmimeSignature",
+	}
+	tests := []struct {
+		name    string
+		sig     *github.Signature
+		policy  *validation.SignaturePolicy
+		keyring *validation.Keyring
+		gitsign validation.SignatureVerifier
+		want    *github.UntrustedCommit
+	}{
+		{
+			name: "unsigned",
+			want: &github.UntrustedCommit{Login: "octocat", SHA: "abc123"},
+		},
+		{
+			name: "valid signature without policy",
+			sig:  gpg,
+		},
+		{
+			name:   "only VALID is accepted by default",
+			sig:    unverifiedEmail,
+			policy: &validation.SignaturePolicy{},
+			want:   &github.UntrustedCommit{Login: "octocat", SHA: "abc123", InvalidSign: unverifiedEmail, SignatureStateRejected: true},
+		},
+		{
+			name: "accepted state",
+			sig:  unverifiedEmail,
+			policy: &validation.SignaturePolicy{
+				AcceptedStates: map[string]struct{}{"UNVERIFIED_EMAIL": {}},
+			},
+		},
+		{
+			name: "type isn't allowed",
+			sig:  gpg,
+			policy: &validation.SignaturePolicy{
+				Types: map[string]struct{}{"ssh": {}},
+			},
+			want: &github.UntrustedCommit{Login: "octocat", SHA: "abc123", InvalidSign: gpg, SignatureTypeRejected: true},
+		},
+		{
+			name: "gpg key id matches a fingerprint",
+			sig:  gpg,
+			policy: &validation.SignaturePolicy{
+				AllowedKeys: map[string][]string{"octocat": {"1111 2222 3333 4444 5555 6666 3afd eb23 aaaa bbbb"}},
+			},
+		},
+		{
+			name: "ssh key isn't allowed",
+			sig:  ssh,
+			policy: &validation.SignaturePolicy{
+				AllowedKeys: map[string][]string{"octocat": {"SHA256:xyz"}},
+			},
+			want: &github.UntrustedCommit{Login: "octocat", SHA: "abc123", InvalidSign: ssh, SignatureKeyRejected: true},
+		},
+		{
+			name: "keys of other users aren't restricted",
+			sig:  ssh,
+			policy: &validation.SignaturePolicy{
+				AllowedKeys: map[string][]string{"someone": {"SHA256:xyz"}},
+			},
+		},
+		{
+			name:    "keyring rejects the signature",
+			sig:     gpg,
+			keyring: rejected,
+			want:    &github.UntrustedCommit{Login: "octocat", SHA: "abc123", InvalidSign: gpg, KeyringError: "no key is registered for octocat"},
+		},
+		{
+			name:    "signature must also be verified by GitHub",
+			sig:     unverifiedEmail,
+			policy:  &validation.SignaturePolicy{},
+			keyring: verified,
+			want:    &github.UntrustedCommit{Login: "octocat", SHA: "abc123", InvalidSign: unverifiedEmail, SignatureStateRejected: true},
+		},
+		{
+			name:    "keyring replaces GitHub's verification",
+			sig:     unverifiedEmail,
+			policy:  &validation.SignaturePolicy{},
+			keyring: &validation.Keyring{Verifier: verified.Verifier, Replace: true},
+		},
+		{
+			name: "gitsign signature isn't verified by GitHub",
+			sig:  x509,
+			want: &github.UntrustedCommit{Login: "octocat", SHA: "abc123", InvalidSign: x509},
+		},
+		{
+			name:    "gitsign verifies the signature instead of GitHub and the keyring",
+			sig:     x509,
+			policy:  &validation.SignaturePolicy{},
+			keyring: rejected,
+			gitsign: verified.Verifier,
+		},
+		{
+			name:    "gitsign rejects the signature",
+			sig:     x509,
+			gitsign: rejected.Verifier,
+			want:    &github.UntrustedCommit{Login: "octocat", SHA: "abc123", InvalidSign: x509, GitsignError: "no key is registered for octocat"},
+		},
+		{
+			name: "x509 isn't allowed by the signature policy",
+			sig:  x509,
+			policy: &validation.SignaturePolicy{
+				Types: map[string]struct{}{"gpg": {}},
+			},
+			gitsign: verified.Verifier,
+			want:    &github.UntrustedCommit{Login: "octocat", SHA: "abc123", InvalidSign: x509, SignatureTypeRejected: true},
+		},
+		{
+			name:    "gitsign doesn't verify GPG signatures",
+			sig:     unverifiedEmail,
+			policy:  &validation.SignaturePolicy{},
+			gitsign: verified.Verifier,
+			want:    &github.UntrustedCommit{Login: "octocat", SHA: "abc123", InvalidSign: unverifiedEmail, SignatureStateRejected: true},
+		},
+		{
+			name:    "keyring doesn't trust unsigned commits",
+			keyring: &validation.Keyring{Verifier: verified.Verifier, Replace: true},
+			want:    &github.UntrustedCommit{Login: "octocat", SHA: "abc123"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := validation.New(&validation.InputNew{})
+			commit := &github.Commit{SHA: "abc123", Signature: tt.sig}
+			got := ctrl.VerifySignature(commit, "octocat", tt.policy, tt.keyring, tt.gitsign)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("VerifySignature() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidator_VerifyCommit_keyring(t *testing.T) {
+	t.Parallel()
+	keyring := &validation.Keyring{
+		Verifier: verifierFunc(func(login, _, _ string) error {
+			return errors.New("no key is registered for " + login)
+		}),
+	}
+	userSig := &github.Signature{IsValid: true, State: "VALID", Typename: "GpgSignature"}
+	githubSig := &github.Signature{IsValid: true, State: "VALID", Typename: "GpgSignature", WasSignedByGitHub: true}
+	tests := []struct {
+		name   string
+		commit *github.Commit
+		want   *github.UntrustedCommit
+	}{
+		{
+			name:   "commit by a trusted app is verified only by GitHub",
+			commit: &github.Commit{SHA: "abc123", Committer: &github.User{Login: "renovate[bot]", IsApp: true}, Signature: userSig},
+		},
+		{
+			name:   "commit signed by GitHub is verified only by GitHub",
+			commit: &github.Commit{SHA: "abc123", Committer: &github.User{Login: "web-flow"}, Signature: githubSig},
+		},
+		{
+			name:   "app commit must still be verified by GitHub",
+			commit: &github.Commit{SHA: "abc123", Committer: &github.User{Login: "renovate[bot]", IsApp: true}, Signature: &github.Signature{State: "INVALID"}},
+			want:   &github.UntrustedCommit{Login: "renovate[bot]", SHA: "abc123", InvalidSign: &github.Signature{State: "INVALID"}},
+		},
+		{
+			name:   "commit by a user is verified with the keyring",
+			commit: &github.Commit{SHA: "abc123", Committer: &github.User{Login: "octocat"}, Signature: userSig},
+			want:   &github.UntrustedCommit{Login: "octocat", SHA: "abc123", InvalidSign: userSig, KeyringError: "no key is registered for octocat"},
+		},
+	}
+	trust := &validation.Trust{
+		TrustedApps:           appMatcher("renovate"),
+		UntrustedMachineUsers: userMatcher(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := validation.New(&validation.InputNew{})
+			got := ctrl.VerifyCommit(tt.commit, trust, nil, nil, keyring, nil)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("VerifyCommit() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResult_ApprovalSummary(t *testing.T) {
+	t.Parallel()
+	result := &validation.Result{
+		ApprovalCount:     1,
+		RequiredApprovals: 3,
+	}
+	if got := result.ApprovalSummary(); got != "1 approval, 3 required" {
+		t.Errorf("ApprovalSummary() = %q, want %q", got, "1 approval, 3 required")
+	}
+}
+
+func TestResult_Reasons(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		result   *validation.Result
+		expected []string
+	}{
+		{
+			name:     "no reasons",
+			result:   &validation.Result{},
+			expected: nil,
+		},
+		{
+			name: "self-approval only",
+			result: &validation.Result{
+				SelfApprovers: map[string][]string{"user1": {"committer"}},
+			},
+			expected: []string{"self-approval"},
+		},
+		{
+			name: "unsigned commit - InvalidSign",
+			result: &validation.Result{
+				UntrustedCommits: []*github.UntrustedCommit{
+					{
+						Login:       "user1",
+						SHA:         "abc123",
+						InvalidSign: &github.Signature{State: "invalid"},
+					},
+				},
+			},
+			expected: []string{"unsigned commits"},
+		},
+		{
+			name: "unsigned commit - NotLinkedToUser",
+			result: &validation.Result{
+				UntrustedCommits: []*github.UntrustedCommit{
+					{
+						SHA:             "abc123",
+						NotLinkedToUser: true,
+					},
+				},
+			},
+			expected: []string{"unsigned commits"},
+		},
+		{
+			name: "untrusted app commit only",
+			result: &validation.Result{
+				UntrustedCommits: []*github.UntrustedCommit{
+					{
+						Login:          "app[bot]",
+						SHA:            "abc123",
+						IsUntrustedApp: true,
+					},
+				},
+			},
+			expected: []string{"untrusted app commits"},
+		},
+		{
+			name: "untrusted machine user commit only",
+			result: &validation.Result{
+				UntrustedCommits: []*github.UntrustedCommit{
+					{
+						Login:                  "bot-user",
+						SHA:                    "abc123",
+						IsUntrustedMachineUser: true,
+					},
+				},
+			},
+			expected: []string{"untrusted machine user commits"},
+		},
+		{
+			name: "unsigned commit and self-approval",
+			result: &validation.Result{
+				SelfApprovers: map[string][]string{"user1": {"committer"}},
+				UntrustedCommits: []*github.UntrustedCommit{
+					{
+						Login:       "user1",
+						SHA:         "abc123",
+						InvalidSign: &github.Signature{State: "invalid"},
+					},
+				},
+			},
+			expected: []string{"unsigned commits", "self-approval"},
+		},
+		{
+			name: "multiple unsigned commits - deduplicated",
+			result: &validation.Result{
+				UntrustedCommits: []*github.UntrustedCommit{
+					{
+						Login:       "user1",
+						SHA:         "abc123",
+						InvalidSign: &github.Signature{State: "invalid"},
+					},
+					{
+						SHA:             "def456",
+						NotLinkedToUser: true,
+					},
+				},
+			},
+			expected: []string{"unsigned commits"},
+		},
+		{
+			name: "all reasons combined",
+			result: &validation.Result{
+				SelfApprovers: map[string][]string{"user1": {"committer"}},
+				UntrustedCommits: []*github.UntrustedCommit{
+					{
+						Login:           "user1",
+						SHA:             "abc123",
+						NotLinkedToUser: true,
+					},
+					{
+						Login:          "app[bot]",
+						SHA:            "def456",
+						IsUntrustedApp: true,
+					},
+					{
+						Login:                  "bot-user",
+						SHA:                    "ghi789",
+						IsUntrustedMachineUser: true,
+					},
+				},
+			},
+			expected: []string{"unsigned commits", "untrusted app commits", "untrusted machine user commits", "self-approval"},
+		},
+		{
+			name: "missing code owners",
+			result: &validation.Result{
+				MissingCodeOwners: []*validation.CodeOwnerRule{
+					{
+						Owners: []string{"@octocat"},
+						Paths:  []string{"README.md"},
+					},
+				},
+			},
+			expected: []string{"code owner approvals"},
+		},
+		{
+			name: "signature policy violation",
+			result: &validation.Result{
+				UntrustedCommits: []*github.UntrustedCommit{
+					{
+						InvalidSign:           &github.Signature{IsValid: true, State: "VALID"},
+						SignatureTypeRejected: true,
+					},
+				},
+			},
+			expected: []string{"signature policy violations"},
+		},
+		{
+			name: "sensitive paths",
+			result: &validation.Result{
+				SensitiveFiles: []string{"CODEOWNERS"},
+			},
+			expected: []string{"sensitive paths"},
 		},
 	}
 